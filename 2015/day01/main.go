@@ -1,32 +1,14 @@
-package main
+package day01
 
 import (
-	"fmt"
+	"errors"
 	"os"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 1, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -37,10 +19,10 @@ func loadInstructions(name string) ([]byte, error) {
 	return os.ReadFile(name)
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	inst, err := loadInstructions(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	floor := 0
@@ -53,19 +35,17 @@ func task1(args []string) error {
 		}
 	}
 
-	fmt.Printf("Floor: %d\n", floor)
-
-	return nil
+	return solver.Int(floor), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	inst, err := loadInstructions(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	floor := 0
@@ -78,10 +58,9 @@ func task2(args []string) error {
 		}
 
 		if floor == -1 {
-			fmt.Printf("First Instruction: %d\n", i+1)
-			break
+			return solver.Int(i + 1), nil
 		}
 	}
 
-	return nil
+	return solver.Answer{}, errors.New("santa never enters the basement")
 }
@@ -1,33 +1,15 @@
-package main
+package day02
 
 import (
 	"bufio"
 	"fmt"
 	"os"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 2, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -74,10 +56,10 @@ func parsePresents(name string) ([]Present, error) {
 	return presents, s.Err()
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	presents, err := parsePresents(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := 0
@@ -85,9 +67,7 @@ func task1(args []string) error {
 		sum += p.WrappingPaper()
 	}
 
-	fmt.Printf("We need %d sqf of wrapping paper!\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -102,10 +82,10 @@ func (p Present) Ribbon() int {
 	return min(a, b, c) + p.L*p.W*p.H
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	presents, err := parsePresents(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := 0
@@ -113,7 +93,5 @@ func task2(args []string) error {
 		sum += p.Ribbon()
 	}
 
-	fmt.Printf("We need %d f of ribbon!\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
@@ -1,4 +1,4 @@
-package main
+package day03
 
 import (
 	"bufio"
@@ -6,31 +6,12 @@ import (
 	"iter"
 	"os"
 
-	"github.com/noxer/aoc/2015/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 3, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -61,16 +42,16 @@ func IterateFile(name string) iter.Seq[byte] {
 	}
 }
 
-var dirs = map[byte]utils.Vec{
+var dirs = map[byte]geom.Vec{
 	'<': {X: -1}, // left / west
 	'>': {X: +1}, // right / east
 	'^': {Y: -1}, // up / north
 	'v': {Y: +1}, // down / south
 }
 
-func task1(args []string) error {
-	pos := utils.Vec{}
-	houses := map[utils.Vec]bool{
+func task1(args []string) (solver.Answer, error) {
+	pos := geom.Vec{}
+	houses := map[geom.Vec]bool{
 		pos: true,
 	}
 
@@ -80,8 +61,7 @@ func task1(args []string) error {
 		houses[pos] = true
 	}
 
-	fmt.Printf("Visited houses: %d\n", len(houses))
-	return nil
+	return solver.Int(len(houses)), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -106,13 +86,12 @@ func IterateDouble[T any](it iter.Seq[T]) iter.Seq2[T, T] {
 			}
 		}
 	}
-
 }
 
-func task2(args []string) error {
-	santa := utils.Vec{}
-	robo := utils.Vec{}
-	houses := map[utils.Vec]bool{
+func task2(args []string) (solver.Answer, error) {
+	santa := geom.Vec{}
+	robo := geom.Vec{}
+	houses := map[geom.Vec]bool{
 		santa: true,
 	}
 
@@ -126,7 +105,5 @@ func task2(args []string) error {
 		houses[robo] = true
 	}
 
-	fmt.Printf("Visited houses: %d\n", len(houses))
-	return nil
-
+	return solver.Int(len(houses)), nil
 }
@@ -1,35 +1,14 @@
-package main
+package day05
 
 import (
-	"fmt"
-	"os"
 	"strings"
 
-	"github.com/noxer/aoc/2015/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 5, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -75,10 +54,10 @@ func check(str string) bool {
 	return checkVowels(str) && checkDouble(str) && checkNotContains(str)
 }
 
-func task1(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task1(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	count := 0
@@ -88,9 +67,7 @@ func task1(args []string) error {
 		}
 	}
 
-	fmt.Printf("Nice: %d\n", count)
-
-	return nil
+	return solver.Int(count), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -122,23 +99,21 @@ func check2(str string) bool {
 	return checkTwice(str) && checkRepeat(str)
 }
 
-func task2(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task2(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	count := 0
 	for _, line := range lines {
 		nice := check2(line)
-		fmt.Printf("%s is nice: %t\n", line, nice)
+		// fmt.Printf("%s is nice: %t\n", line, nice)
 
 		if nice {
 			count++
 		}
 	}
 
-	fmt.Printf("Nice: %d\n", count)
-
-	return nil
+	return solver.Int(count), nil
 }
@@ -1,36 +1,17 @@
-package main
+package day06
 
 import (
-	"fmt"
-	"os"
 	"regexp"
 	"strconv"
 
-	"github.com/noxer/aoc/2015/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/intervals"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 6, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -46,7 +27,7 @@ const (
 )
 
 type Inst struct {
-	A, B    utils.Vec
+	A, B    geom.Vec
 	Command Cmd
 }
 
@@ -78,44 +59,56 @@ func parseInst(line string) Inst {
 	return inst
 }
 
-func (i Inst) Apply(grid *[1000][1000]bool) {
-	for y := i.A.Y; y <= i.B.Y; y++ {
-		for x := i.A.X; x <= i.B.X; x++ {
-			switch i.Command {
-			case TurnOn:
-				(*grid)[y][x] = true
-			case TurnOff:
-				(*grid)[y][x] = false
-			case Toggle:
-				(*grid)[y][x] = !(*grid)[y][x]
-			}
+// Box returns the lights the instruction affects.
+func (i Inst) Box() intervals.Box {
+	return intervals.Box{intervals.Closed(i.A.X, i.B.X), intervals.Closed(i.A.Y, i.B.Y)}
+}
+
+// subtract removes the box from the disjoint boxes.
+func subtract(boxes []intervals.Box, b intervals.Box) []intervals.Box {
+	var res []intervals.Box
+	for _, box := range boxes {
+		res = append(res, box.Difference(b)...)
+	}
+	return res
+}
+
+// Apply changes the lit lights, which are kept as disjoint boxes.
+func (i Inst) Apply(lit []intervals.Box) []intervals.Box {
+	b := i.Box()
+
+	switch i.Command {
+	case TurnOn:
+		return append(subtract(lit, b), b)
+	case TurnOff:
+		return subtract(lit, b)
+	default:
+		// the lights in b which are off turn on
+		off := []intervals.Box{b}
+		for _, box := range lit {
+			off = subtract(off, box)
 		}
+		return append(subtract(lit, b), off...)
 	}
 }
 
-func task1(args []string) error {
-	insts, err := utils.ReadLinesTransform(args[0], parseInst)
+func task1(args []string) (solver.Answer, error) {
+	insts, err := parse.ReadLinesTransform(args[0], parseInst)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	var grid [1000][1000]bool
+	var lit []intervals.Box
 	for _, inst := range insts {
-		inst.Apply(&grid)
+		lit = inst.Apply(lit)
 	}
 
 	count := 0
-	for y := range 1000 {
-		for x := range 1000 {
-			if grid[y][x] {
-				count++
-			}
-		}
+	for _, box := range lit {
+		count += box.Volume()
 	}
 
-	fmt.Printf("Lit lights: %d\n", count)
-
-	return nil
+	return solver.Int(count), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -140,10 +133,10 @@ func (i Inst) Apply2(grid *[1000][1000]int) {
 	}
 }
 
-func task2(args []string) error {
-	insts, err := utils.ReadLinesTransform(args[0], parseInst)
+func task2(args []string) (solver.Answer, error) {
+	insts, err := parse.ReadLinesTransform(args[0], parseInst)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	var grid [1000][1000]int
@@ -158,8 +151,5 @@ func task2(args []string) error {
 		}
 	}
 
-	fmt.Printf("Total brightness: %d\n", count)
-
-	return nil
-
+	return solver.Int(count), nil
 }
@@ -1,34 +1,12 @@
-package main
+package day08
 
 import (
-	"fmt"
-	"os"
-
-	"github.com/noxer/aoc/2015/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 8, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -54,21 +32,19 @@ func countUnescaped(str string) int {
 	return count - 2
 }
 
-func task1(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task1(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := 0
 	for _, line := range lines {
-		fmt.Printf("%s -> %d\n", line, countUnescaped(line))
+		// fmt.Printf("%s -> %d\n", line, countUnescaped(line))
 		sum += len(line) - countUnescaped(line)
 	}
 
-	fmt.Printf("Difference: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -90,10 +66,10 @@ func countEscaped(str string) int {
 	return count + 2
 }
 
-func task2(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task2(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := 0
@@ -102,7 +78,5 @@ func task2(args []string) error {
 		sum += countEscaped(line) - len(line)
 	}
 
-	fmt.Printf("Difference: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
@@ -1,4 +1,4 @@
-package main
+package day09
 
 import (
 	"bufio"
@@ -6,33 +6,13 @@ import (
 	"math"
 	"os"
 	"slices"
-	"strings"
 
-	"github.com/noxer/aoc/2015/utils"
+	"github.com/noxer/aoc/lib/collections"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 9, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -98,13 +78,13 @@ func (p Path) Score() int {
 	return p.Length
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	graph, err := loadDistances(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	paths := utils.NewHeap(Path.Score)
+	paths := collections.NewHeap(Path.Score)
 	for loc := range graph {
 		paths.Push(Path{
 			Location: loc,
@@ -120,15 +100,7 @@ func task1(args []string) error {
 
 		for _, next := range path.Calc(graph) {
 			if len(next.Seen) == len(graph)-1 {
-				if next.Length < shortestPath {
-					fmt.Printf(
-						"Found path: %s, %s with length %d\n",
-						strings.Join(next.Seen, ", "),
-						next.Location,
-						next.Length,
-					)
-					shortestPath = next.Length
-				}
+				shortestPath = min(shortestPath, next.Length)
 				continue
 			}
 
@@ -136,20 +108,20 @@ func task1(args []string) error {
 		}
 	}
 
-	return nil
+	return solver.Int(shortestPath), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	graph, err := loadDistances(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	paths := utils.NewHeap(Path.Score)
+	paths := collections.NewHeap(Path.Score)
 	for loc := range graph {
 		paths.Push(Path{
 			Location: loc,
@@ -162,15 +134,7 @@ func task2(args []string) error {
 
 		for _, next := range path.Calc(graph) {
 			if len(next.Seen) == len(graph)-1 {
-				if next.Length > longestPath {
-					fmt.Printf(
-						"Found path: %s, %s with length %d\n",
-						strings.Join(next.Seen, ", "),
-						next.Location,
-						next.Length,
-					)
-					longestPath = next.Length
-				}
+				longestPath = max(longestPath, next.Length)
 				continue
 			}
 
@@ -178,5 +142,5 @@ func task2(args []string) error {
 		}
 	}
 
-	return nil
+	return solver.Int(longestPath), nil
 }
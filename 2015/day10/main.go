@@ -1,34 +1,14 @@
-package main
+package day10
 
 import (
-	"fmt"
-	"os"
 	"strconv"
 	"strings"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.RegisterBuiltin(2015, 10, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -60,30 +40,26 @@ func lookSay(seq string) string {
 	return sb.String()
 }
 
-func task1(_ []string) error {
+func task1(_ []string) (solver.Answer, error) {
 	seq := "1113222113"
 
 	for range 40 {
 		seq = lookSay(seq)
 	}
 
-	fmt.Printf("Length: %d\n", len(seq))
-
-	return nil
+	return solver.Int(len(seq)), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(_ []string) error {
+func task2(_ []string) (solver.Answer, error) {
 	seq := "1113222113"
 
 	for range 50 {
 		seq = lookSay(seq)
 	}
 
-	fmt.Printf("Length: %d\n", len(seq))
-
-	return nil
+	return solver.Int(len(seq)), nil
 }
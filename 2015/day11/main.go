@@ -1,33 +1,13 @@
-package main
+package day11
 
 import (
-	"fmt"
-	"os"
 	"strings"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.RegisterBuiltin(2015, 11, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -93,20 +73,18 @@ func checkPass(pass []byte) bool {
 	return checkStraight(pass) && checkDoubles(pass)
 }
 
-func task1(_ []string) error {
+func task1(_ []string) (solver.Answer, error) {
 	pass := nextPassword([]byte("vzbxxyza"), checkPass)
-	fmt.Println(string(pass))
 
-	return nil
+	return solver.String(string(pass)), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	pass := nextPassword([]byte("vzbxxzaa"), checkPass)
-	fmt.Println(string(pass))
 
-	return nil
+	return solver.String(string(pass)), nil
 }
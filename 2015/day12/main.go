@@ -1,33 +1,14 @@
-package main
+package day12
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
-)
-
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 12, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -76,16 +57,15 @@ func sumNumbers(d any) int64 {
 	return sum
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	doc, err := parseDocument(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := sumNumbers(doc)
-	fmt.Printf("Sum: %d\n", sum)
 
-	return nil
+	return solver.Int(int(sum)), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -129,14 +109,13 @@ func sumNonRedNumbers(d any) int64 {
 	return sum
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	doc, err := parseDocument(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := sumNonRedNumbers(doc)
-	fmt.Printf("Sum: %d\n", sum)
 
-	return nil
+	return solver.Int(int(sum)), nil
 }
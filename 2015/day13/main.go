@@ -1,4 +1,4 @@
-package main
+package day13
 
 import (
 	"bufio"
@@ -9,30 +9,12 @@ import (
 	"regexp"
 	"slices"
 	"strconv"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 13, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -114,33 +96,30 @@ func permuteRecursive[S ~[]T, T any](full, part S, yield func(S) bool) bool {
 	return true
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	friends, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	table := slices.Collect(maps.Keys(friends))
 
 	bestHappiness := 0
 	for table := range permute(table) {
-		fmt.Println(table)
 		bestHappiness = max(bestHappiness, calculateHappiness(friends, table))
 	}
 
-	fmt.Printf("Happiness: %d\n", bestHappiness)
-
-	return nil
+	return solver.Int(bestHappiness), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	friends, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	table := slices.Collect(maps.Keys(friends))
@@ -148,11 +127,8 @@ func task2(args []string) error {
 
 	bestHappiness := 0
 	for table := range permute(table) {
-		fmt.Println(table)
 		bestHappiness = max(bestHappiness, calculateHappiness(friends, table))
 	}
 
-	fmt.Printf("Happiness: %d\n", bestHappiness)
-
-	return nil
+	return solver.Int(bestHappiness), nil
 }
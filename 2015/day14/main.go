@@ -1,4 +1,4 @@
-package main
+package day14
 
 import (
 	"bufio"
@@ -6,30 +6,13 @@ import (
 	"os"
 	"regexp"
 	"strconv"
-)
-
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 14, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -93,35 +76,45 @@ func parseFile(name string) (map[string]Reindeer, error) {
 	return rs, s.Err()
 }
 
-func task1(args []string) error {
+// task1 returns the distance of the winning reindeer after 2503 seconds. The length of the race
+// can be passed after the input file.
+func task1(args []string) (solver.Answer, error) {
 	reindeers, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
+	}
+
+	seconds, err := parse.IntArg(args, 1, 2503)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	best := 0
-	seconds := 2503
 	for _, reindeer := range reindeers {
 		best = max(best, reindeer.DistanceAfterSeconds(seconds))
 	}
 
-	fmt.Printf("Best distance: %d\n", best)
-
-	return nil
+	return solver.Int(best), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
+// task2 returns the points of the winning reindeer. It accepts the length of the race like task1.
+func task2(args []string) (solver.Answer, error) {
 	reindeers, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
+	}
+
+	length, err := parse.IntArg(args, 1, 2503)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	points := make(map[string]int)
-	for seconds := range 2503 {
+	for seconds := range length {
 		best := 0
 
 		for _, reindeer := range reindeers {
@@ -135,9 +128,10 @@ func task2(args []string) error {
 		}
 	}
 
-	for name, ps := range points {
-		fmt.Printf("%s: %d\n", name, ps)
+	best := 0
+	for _, ps := range points {
+		best = max(best, ps)
 	}
 
-	return nil
+	return solver.Int(best), nil
 }
@@ -1,37 +1,18 @@
-package main
+package day15
 
 import (
 	"bufio"
-	"fmt"
 	"maps"
 	"os"
 	"slices"
 	"strconv"
 	"strings"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 15, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -111,18 +92,16 @@ func score(ingredients []Ingredient, current Ingredient, remaining int) int {
 	return best
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	ingredients, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	values := slices.Collect(maps.Values(ingredients))
 	best := score(values, make(Ingredient), 100)
 
-	fmt.Printf("Best: %d\n", best)
-
-	return nil
+	return solver.Int(best), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -153,16 +132,14 @@ func scoreCalories(ingredients []Ingredient, current Ingredient, remaining int)
 	return best
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	ingredients, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	values := slices.Collect(maps.Values(ingredients))
 	best := scoreCalories(values, make(Ingredient), 100)
 
-	fmt.Printf("Best: %d\n", best)
-
-	return nil
+	return solver.Int(best), nil
 }
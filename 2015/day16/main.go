@@ -1,36 +1,16 @@
-package main
+package day16
 
 import (
-	"fmt"
-	"os"
+	"errors"
 	"strconv"
 	"strings"
 
-	"github.com/noxer/aoc/2015/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 16, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -63,10 +43,10 @@ func parseAunt(line string) Sue {
 	return sue
 }
 
-func task1(args []string) error {
-	aunts, err := utils.ReadLinesTransform(args[0], parseAunt)
+func task1(args []string) (solver.Answer, error) {
+	aunts, err := parse.ReadLinesTransform(args[0], parseAunt)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	reference := Sue{
@@ -84,11 +64,11 @@ func task1(args []string) error {
 
 	for i, aunt := range aunts {
 		if aunt.Matches(reference) {
-			fmt.Printf("Sue #%d matches the reference!\n", i+1)
+			return solver.Int(i + 1), nil
 		}
 	}
 
-	return nil
+	return solver.Answer{}, errors.New("no Sue matches the reference")
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -116,10 +96,10 @@ func (s Sue) Matches2(other Sue) bool {
 	return true
 }
 
-func task2(args []string) error {
-	aunts, err := utils.ReadLinesTransform(args[0], parseAunt)
+func task2(args []string) (solver.Answer, error) {
+	aunts, err := parse.ReadLinesTransform(args[0], parseAunt)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	reference := Sue{
@@ -137,9 +117,9 @@ func task2(args []string) error {
 
 	for i, aunt := range aunts {
 		if aunt.Matches2(reference) {
-			fmt.Printf("Sue #%d matches the reference!\n", i+1)
+			return solver.Int(i + 1), nil
 		}
 	}
 
-	return nil
+	return solver.Answer{}, errors.New("no Sue matches the reference")
 }
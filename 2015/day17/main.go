@@ -1,36 +1,15 @@
-package main
+package day17
 
 import (
-	"fmt"
 	"math"
-	"os"
 	"strconv"
 
-	"github.com/noxer/aoc/2015/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 17, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -54,19 +33,25 @@ func fill(containers []int, remaining int) int {
 	return n
 }
 
-func task1(args []string) error {
-	containers, err := utils.ReadLinesTransform(args[0], func(line string) int {
+// task1 counts the combinations of containers holding 150 liters. The amount can be passed after
+// the input file.
+func task1(args []string) (solver.Answer, error) {
+	containers, err := parse.ReadLinesTransform(args[0], func(line string) int {
 		n, _ := strconv.Atoi(line)
 		return n
 	})
 	if err != nil {
-		return err
+		return solver.Answer{}, err
+	}
+
+	liters, err := parse.IntArg(args, 1, 150)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	n := fill(containers, 150)
-	fmt.Printf("Combinations: %d\n", n)
+	n := fill(containers, liters)
 
-	return nil
+	return solver.Int(n), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -110,20 +95,23 @@ func matchCont(containers []int, remaining, used, match int) int {
 	return n
 }
 
-func task2(args []string) error {
-	containers, err := utils.ReadLinesTransform(args[0], func(line string) int {
+// task2 counts the combinations with the fewest containers. It accepts the amount like task1.
+func task2(args []string) (solver.Answer, error) {
+	containers, err := parse.ReadLinesTransform(args[0], func(line string) int {
 		n, _ := strconv.Atoi(line)
 		return n
 	})
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	min := minCont(containers, 150, 0)
-	fmt.Printf("Min: %d\n", min)
+	liters, err := parse.IntArg(args, 1, 150)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-	n := matchCont(containers, 150, 0, min)
-	fmt.Printf("Match: %d\n", n)
+	min := minCont(containers, liters, 0)
+	n := matchCont(containers, liters, 0, min)
 
-	return nil
+	return solver.Int(n), nil
 }
@@ -1,45 +1,26 @@
-package main
+package day18
 
 import (
 	"bufio"
-	"fmt"
 	"os"
 
-	"github.com/noxer/aoc/2015/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 18, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-type Grid map[utils.Vec]struct{}
+type Grid map[geom.Vec]struct{}
 
-func (g Grid) CountNeighbors(pos utils.Vec) int {
-	off := utils.Vec{}
+func (g Grid) CountNeighbors(pos geom.Vec) int {
+	off := geom.Vec{}
 	count := 0
 
 	for off.X = -1; off.X <= 1; off.X++ {
@@ -57,7 +38,7 @@ func (g Grid) CountNeighbors(pos utils.Vec) int {
 	return count
 }
 
-func (g Grid) NextCell(pos utils.Vec) bool {
+func (g Grid) NextCell(pos geom.Vec) bool {
 	_, alive := g[pos]
 	if !alive {
 		return g.CountNeighbors(pos) == 3
@@ -71,12 +52,13 @@ func (g Grid) NextCell(pos utils.Vec) bool {
 	}
 }
 
-func (g Grid) Next() Grid {
+// Next returns the lights after one step on a square grid of the given size.
+func (g Grid) Next(size int) Grid {
 	next := make(Grid)
-	pos := utils.Vec{}
+	pos := geom.Vec{}
 
-	for pos.X = 0; pos.X < 100; pos.X++ {
-		for pos.Y = 0; pos.Y < 100; pos.Y++ {
+	for pos.X = 0; pos.X < size; pos.X++ {
+		for pos.Y = 0; pos.Y < size; pos.Y++ {
 			if g.NextCell(pos) {
 				next[pos] = struct{}{}
 			}
@@ -86,17 +68,18 @@ func (g Grid) Next() Grid {
 	return next
 }
 
-func loadGrid(name string) (Grid, error) {
+// loadGrid reads the lights and the size of the grid.
+func loadGrid(name string) (Grid, int, error) {
 	f, err := os.Open(name)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer f.Close()
 
 	s := bufio.NewScanner(f)
 
 	grid := make(Grid)
-	pos := utils.Vec{}
+	pos := geom.Vec{}
 	for s.Scan() {
 		for x, b := range s.Bytes() {
 			if b != '#' {
@@ -110,64 +93,65 @@ func loadGrid(name string) (Grid, error) {
 		pos.Y++
 	}
 
-	return grid, nil
+	return grid, pos.Y, s.Err()
 }
 
-func task1(args []string) error {
-	grid, err := loadGrid(args[0])
+// task1 counts the lights after 100 steps. The number of steps can be passed after the input file.
+func task1(args []string) (solver.Answer, error) {
+	grid, size, err := loadGrid(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	for range 100 {
-		grid = grid.Next()
+	steps, err := parse.IntArg(args, 1, 100)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	fmt.Printf("%d lights are on\n", len(grid))
+	for range steps {
+		grid = grid.Next(size)
+	}
 
-	return nil
+	return solver.Int(len(grid)), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func (g Grid) Next2() Grid {
-	next := make(Grid)
-	next[utils.Vec{0, 0}] = struct{}{}
-	next[utils.Vec{0, 99}] = struct{}{}
-	next[utils.Vec{99, 0}] = struct{}{}
-	next[utils.Vec{99, 99}] = struct{}{}
-
-	pos := utils.Vec{}
-
-	for pos.X = 0; pos.X < 100; pos.X++ {
-		for pos.Y = 0; pos.Y < 100; pos.Y++ {
-			if g.NextCell(pos) {
-				next[pos] = struct{}{}
-			}
-		}
-	}
+// corners turns on the lights in the corners of the grid.
+func (g Grid) corners(size int) {
+	g[geom.Vec{X: 0, Y: 0}] = struct{}{}
+	g[geom.Vec{X: 0, Y: size - 1}] = struct{}{}
+	g[geom.Vec{X: size - 1, Y: 0}] = struct{}{}
+	g[geom.Vec{X: size - 1, Y: size - 1}] = struct{}{}
+}
 
+// Next2 works like Next but the corners are stuck on.
+func (g Grid) Next2(size int) Grid {
+	next := g.Next(size)
+	next.corners(size)
 	return next
 }
 
-func task2(args []string) error {
-	grid, err := loadGrid(args[0])
+// task2 counts the lights after 100 steps with the corners stuck on. It accepts the number of
+// steps like task1.
+func task2(args []string) (solver.Answer, error) {
+	grid, size, err := loadGrid(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	grid[utils.Vec{0, 0}] = struct{}{}
-	grid[utils.Vec{0, 99}] = struct{}{}
-	grid[utils.Vec{99, 0}] = struct{}{}
-	grid[utils.Vec{99, 99}] = struct{}{}
-
-	for range 100 {
-		grid = grid.Next2()
+	steps, err := parse.IntArg(args, 1, 100)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	fmt.Printf("%d lights are on\n", len(grid))
+	grid.corners(size)
+
+	for range steps {
+		grid = grid.Next2(size)
+	}
 
-	return nil
+	return solver.Int(len(grid)), nil
 }
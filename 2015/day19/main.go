@@ -1,35 +1,16 @@
-package main
+package day19
 
 import (
 	"bufio"
-	"fmt"
 	"math"
 	"os"
 	"strings"
-)
-
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2015, 19, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -76,16 +57,15 @@ func replacements(ts map[string][]string, str string) map[string]struct{} {
 	return set
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	ts, mo, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	re := replacements(ts, mo)
-	fmt.Printf("Distict: %d\n", len(re))
 
-	return nil
+	return solver.Int(len(re)), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -101,7 +81,7 @@ var cache = map[key]int{}
 
 func expand(ts map[string][]string, expected, molecule string, steps int) int {
 	if molecule == expected {
-		fmt.Printf("Found steps: %d\n", steps)
+		// fmt.Printf("Found steps: %d\n", steps)
 		return steps
 	}
 
@@ -136,16 +116,15 @@ func invert(m map[string][]string) map[string][]string {
 	return o
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	ts, mo, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	st := invert(ts)
 
 	best := expand(st, "e", mo, 0)
-	fmt.Printf("Best: %d (max int: %d)\n", best, math.MaxInt)
 
-	return nil
+	return solver.Int(best), nil
 }
@@ -1,32 +1,14 @@
-package main
+package day20
 
 import (
-	"fmt"
-	"os"
-)
-
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"errors"
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.RegisterBuiltin(2015, 20, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -46,19 +28,23 @@ func calcPresents(house int) int {
 	return presents
 }
 
-func task1(args []string) error {
-	const max = 33100000
+// task1 finds the first house getting at least 33100000 presents. Another number can be passed as
+// argument.
+func task1(args []string) (solver.Answer, error) {
+	target, err := parse.IntArg(args, 0, 33100000)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
 	for i := range 1_000_000 {
 		presents := calcPresents(i + 1)
-		fmt.Printf("House %d gets %d presents\n", i+1, presents)
 
-		if presents >= max {
-			break
+		if presents >= target {
+			return solver.Int(i + 1), nil
 		}
 	}
 
-	return nil
+	return solver.Answer{}, errors.New("no house gets enough presents")
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -78,17 +64,20 @@ func calcPresents2(house int) int {
 	return presents
 }
 
-func task2(args []string) error {
-	const max = 33100000
+// task2 works like task1 with the lazy elves.
+func task2(args []string) (solver.Answer, error) {
+	target, err := parse.IntArg(args, 0, 33100000)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
 	for i := range 1_000_000 {
 		presents := calcPresents2(i + 1)
-		fmt.Printf("House %d gets %d presents\n", i+1, presents)
 
-		if presents >= max {
-			break
+		if presents >= target {
+			return solver.Int(i + 1), nil
 		}
 	}
 
-	return nil
+	return solver.Answer{}, errors.New("no house gets enough presents")
 }
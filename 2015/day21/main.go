@@ -1,32 +1,11 @@
-package main
+package day21
 
 import (
-	"fmt"
-	"os"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.RegisterBuiltin(2015, 21, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -103,7 +82,7 @@ func fight(player, boss *Duelist) bool {
 	}
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	cost := 99999
 
 	for _, w := range weapons {
@@ -126,16 +105,14 @@ func task1(args []string) error {
 		}
 	}
 
-	fmt.Printf("Best fight for money: %d\n", cost)
-
-	return nil
+	return solver.Int(cost), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	cost := 0
 
 	for _, w := range weapons {
@@ -158,7 +135,5 @@ func task2(args []string) error {
 		}
 	}
 
-	fmt.Printf("Worst fight for money: %d\n", cost)
-
-	return nil
+	return solver.Int(cost), nil
 }
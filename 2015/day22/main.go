@@ -1,32 +1,11 @@
-package main
+package day22
 
 import (
-	"fmt"
-	"os"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.RegisterBuiltin(2015, 22, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -46,7 +25,7 @@ type Spell struct {
 }
 
 var spells = []Spell{
-	{53, 4, 0, 0},
+	{Cost: 53, InstantDamage: 4},
 	{},
 	{},
 	{},
@@ -69,14 +48,14 @@ func (d Duelist) Hit(other *Duelist) {
 	other.Hitpoints -= points
 }
 
-func task1(args []string) error {
-	return nil
+func task1(args []string) (solver.Answer, error) {
+	return solver.Answer{}, nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
-	return nil
+func task2(args []string) (solver.Answer, error) {
+	return solver.Answer{}, nil
 }
@@ -0,0 +1,421 @@
+package day19
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/noxer/aoc/lib/intervals"
+)
+
+const (
+	Accept = "A"
+	Reject = "R"
+
+	start = "in"
+)
+
+// dimensions maps the categories to the dimensions of the boxes of parts.
+var dimensions = map[string]int{"x": 0, "m": 1, "a": 2, "s": 3}
+
+// Rule sends a part to Target if its rating Key compares to Value with Op ('<' or '>'). A rule
+// without Op matches every part.
+type Rule struct {
+	Key    string
+	Op     byte
+	Value  int
+	Target string
+}
+
+func parseRule(raw string) (Rule, error) {
+	cond, target, found := strings.Cut(raw, ":")
+	if !found {
+		return Rule{Target: raw}, nil
+	}
+
+	i := strings.IndexAny(cond, "<>")
+	if i < 0 {
+		return Rule{}, fmt.Errorf("invalid condition %q", cond)
+	}
+
+	r := Rule{Key: cond[:i], Op: cond[i], Target: target}
+	if _, ok := dimensions[r.Key]; !ok {
+		return Rule{}, fmt.Errorf("unknown category %q", r.Key)
+	}
+
+	var err error
+	if r.Value, err = strconv.Atoi(cond[i+1:]); err != nil {
+		return Rule{}, fmt.Errorf("invalid value in %q: %w", cond, err)
+	}
+
+	return r, nil
+}
+
+// Matches reports whether the part satisfies the condition of the rule.
+func (r Rule) Matches(p Part) bool {
+	switch r.Op {
+	case '<':
+		return p[r.Key] < r.Value
+	case '>':
+		return p[r.Key] > r.Value
+	}
+	return true
+}
+
+// Split divides the box into the parts matching the rule and the rest.
+func (r Rule) Split(b intervals.Box) (match, rest intervals.Box) {
+	dim := dimensions[r.Key]
+	switch r.Op {
+	case '<':
+		match, rest = b.Split(dim, r.Value)
+	case '>':
+		rest, match = b.Split(dim, r.Value+1)
+	default:
+		match, rest = b, b.With(0, intervals.Interval{})
+	}
+	return match, rest
+}
+
+// Condition formats the condition of the rule, "else" for rules without one.
+func (r Rule) Condition() string {
+	if r.Op == 0 {
+		return "else"
+	}
+	return r.Key + string(r.Op) + strconv.Itoa(r.Value)
+}
+
+func (r Rule) String() string {
+	if r.Op == 0 {
+		return r.Target
+	}
+	return r.Condition() + ":" + r.Target
+}
+
+// Workflow is a list of rules, the last one has no condition.
+type Workflow struct {
+	Name  string
+	Rules []Rule
+}
+
+func ParseWorkflow(line string) (*Workflow, error) {
+	name, rawRules, found := strings.Cut(line, "{")
+	if !found || !strings.HasSuffix(rawRules, "}") {
+		return nil, fmt.Errorf("invalid workflow %q", line)
+	}
+
+	w := &Workflow{Name: name}
+	for _, raw := range strings.Split(strings.TrimSuffix(rawRules, "}"), ",") {
+		r, err := parseRule(raw)
+		if err != nil {
+			return nil, fmt.Errorf("workflow %s: %w", name, err)
+		}
+		w.Rules = append(w.Rules, r)
+	}
+
+	for i, r := range w.Rules {
+		if (r.Op == 0) != (i == len(w.Rules)-1) {
+			return nil, fmt.Errorf("workflow %s: only the last rule has no condition", name)
+		}
+	}
+
+	return w, nil
+}
+
+// Next returns the target of the first rule matching the part.
+func (w *Workflow) Next(p Part) string {
+	for _, r := range w.Rules {
+		if r.Matches(p) {
+			return r.Target
+		}
+	}
+	panic("day19: workflow without fallback rule")
+}
+
+func (w *Workflow) String() string {
+	rules := make([]string, len(w.Rules))
+	for i, r := range w.Rules {
+		rules[i] = r.String()
+	}
+	return w.Name + "{" + strings.Join(rules, ",") + "}"
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// System is the set of workflows, starting with the workflow "in". It is guaranteed to be free of
+// cycles, so every part ends up accepted or rejected.
+type System struct {
+	workflows map[string]*Workflow
+	names     []string // in the order of the input
+}
+
+// ParseSystem parses the workflows and checks that all targets exist and that there are no
+// cycles.
+func ParseSystem(lines []string) (*System, error) {
+	s := &System{workflows: make(map[string]*Workflow)}
+	for _, line := range lines {
+		w, err := ParseWorkflow(line)
+		if err != nil {
+			return nil, err
+		}
+		if w.Name == Accept || w.Name == Reject {
+			return nil, fmt.Errorf("workflow %s shadows a final state", w.Name)
+		}
+		if _, ok := s.workflows[w.Name]; ok {
+			return nil, fmt.Errorf("workflow %s is defined twice", w.Name)
+		}
+
+		s.workflows[w.Name] = w
+		s.names = append(s.names, w.Name)
+	}
+
+	if _, ok := s.workflows[start]; !ok {
+		return nil, errors.New("there is no workflow " + start)
+	}
+
+	for _, name := range s.names {
+		for _, r := range s.workflows[name].Rules {
+			if _, ok := s.workflows[r.Target]; !ok && !final(r.Target) {
+				return nil, fmt.Errorf("workflow %s sends parts to the unknown workflow %s", name, r.Target)
+			}
+		}
+	}
+
+	if err := s.checkCycles(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func final(name string) bool {
+	return name == Accept || name == Reject
+}
+
+func (s *System) Workflow(name string) (*Workflow, bool) {
+	w, ok := s.workflows[name]
+	return w, ok
+}
+
+// checkCycles returns an error describing the first cycle between the workflows.
+func (s *System) checkCycles() error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if final(name) {
+			return nil
+		}
+
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			cycle := append(path[slices.Index(path, name):], name)
+			return fmt.Errorf("workflows form a cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, r := range s.workflows[name].Rules {
+			if err := visit(r.Target); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+
+		return nil
+	}
+
+	for _, name := range s.names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Accepts runs the part through the workflows.
+func (s *System) Accepts(p Part) bool {
+	name := start
+	for !final(name) {
+		name = s.workflows[name].Next(p)
+	}
+	return name == Accept
+}
+
+// Count returns the number of parts in the box which are accepted.
+func (s *System) Count(b intervals.Box) int {
+	count := 0
+	s.walk(start, b, func(name string, i int, match intervals.Box) {
+		if s.workflows[name].Rules[i].Target == Accept {
+			count += match.Volume()
+		}
+	})
+	return count
+}
+
+// walk evaluates the workflows symbolically. The visitor is called for every rule matching a
+// non-empty part of the box.
+func (s *System) walk(name string, b intervals.Box, visit func(name string, i int, match intervals.Box)) {
+	for i, r := range s.workflows[name].Rules {
+		var match intervals.Box
+		match, b = r.Split(b)
+
+		if !match.Empty() {
+			visit(name, i, match)
+			if !final(r.Target) {
+				s.walk(r.Target, match, visit)
+			}
+		}
+
+		if b.Empty() {
+			return
+		}
+	}
+}
+
+// Unreachable returns the workflows which aren't referenced from "in" directly or indirectly.
+func (s *System) Unreachable() []string {
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		for _, r := range s.workflows[name].Rules {
+			if !final(r.Target) && !seen[r.Target] {
+				seen[r.Target] = true
+				queue = append(queue, r.Target)
+			}
+		}
+	}
+
+	var unreachable []string
+	for _, name := range s.names {
+		if !seen[name] {
+			unreachable = append(unreachable, name)
+		}
+	}
+	return unreachable
+}
+
+// Simplify removes the rules which never match a part with ratings from 1 to 4000 and the
+// workflows no such part reaches. Rules sending parts to the same target as the following rule are
+// merged and workflows consisting of a single rule are inlined. It returns the number of removed
+// rules.
+func (s *System) Simplify() int {
+	before := s.rules()
+
+	type ruleRef struct {
+		name string
+		i    int
+	}
+	matched := make(map[ruleRef]bool)
+	reached := map[string]bool{start: true}
+
+	all := intervals.Closed(1, 4000)
+	s.walk(start, intervals.Box{all, all, all, all}, func(name string, i int, _ intervals.Box) {
+		matched[ruleRef{name, i}] = true
+		reached[s.workflows[name].Rules[i].Target] = true
+	})
+
+	for _, name := range slices.Clone(s.names) {
+		if !reached[name] {
+			s.remove(name)
+			continue
+		}
+
+		// the last matching rule takes all remaining parts, so it becomes the fallback
+		w := s.workflows[name]
+		var rules []Rule
+		for i, r := range w.Rules {
+			if matched[ruleRef{name, i}] {
+				rules = append(rules, r)
+			}
+		}
+		rules[len(rules)-1] = Rule{Target: rules[len(rules)-1].Target}
+		w.Rules = rules
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, name := range slices.Clone(s.names) {
+			w := s.workflows[name]
+			for n := len(w.Rules); n > 1 && w.Rules[n-2].Target == w.Rules[n-1].Target; n-- {
+				w.Rules = append(w.Rules[:n-2], Rule{Target: w.Rules[n-1].Target})
+				changed = true
+			}
+
+			if len(w.Rules) == 1 && name != start {
+				s.replace(name, w.Rules[0].Target)
+				s.remove(name)
+				changed = true
+			}
+		}
+	}
+
+	return before - s.rules()
+}
+
+func (s *System) rules() int {
+	n := 0
+	for _, w := range s.workflows {
+		n += len(w.Rules)
+	}
+	return n
+}
+
+// replace redirects all rules sending parts to the workflow old to target.
+func (s *System) replace(old, target string) {
+	for _, w := range s.workflows {
+		for i := range w.Rules {
+			if w.Rules[i].Target == old {
+				w.Rules[i].Target = target
+			}
+		}
+	}
+}
+
+func (s *System) remove(name string) {
+	delete(s.workflows, name)
+	s.names = slices.DeleteFunc(s.names, func(n string) bool { return n == name })
+}
+
+// String formats the workflows like the input.
+func (s *System) String() string {
+	b := &strings.Builder{}
+	for _, name := range s.names {
+		fmt.Fprintln(b, s.workflows[name])
+	}
+	return b.String()
+}
+
+// Tree formats the workflows as a decision tree starting at "in".
+func (s *System) Tree() string {
+	b := &strings.Builder{}
+	fmt.Fprintln(b, start)
+
+	var format func(name, indent string)
+	format = func(name, indent string) {
+		for _, r := range s.workflows[name].Rules {
+			fmt.Fprintf(b, "%s%s -> %s\n", indent, r.Condition(), r.Target)
+			if !final(r.Target) {
+				format(r.Target, indent+"  ")
+			}
+		}
+	}
+	format(start, "  ")
+
+	return b.String()
+}
@@ -1,57 +1,120 @@
-package main
+package day20
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
-	"github.com/noxer/aoc/2023/utils"
+	"github.com/noxer/aoc/lib/collections"
+	"github.com/noxer/aoc/lib/cycle"
+	"github.com/noxer/aoc/lib/debug"
+	"github.com/noxer/aoc/lib/dot"
+	"github.com/noxer/aoc/lib/mathx"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2023, 20, task1, task2)
+	solver.RegisterGraph(2023, 20, graph)
+	solver.RegisterDebug(2023, 20, debugNetwork)
+}
 
+// task1 multiplies the low and high pulses sent in 1000 button presses. The number of presses can
+// be passed after the input file.
+func task1(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
+		return solver.Answer{}, err
 	}
-}
 
-func task1(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+	presses, err := parse.IntArg(args, 1, 1000)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	_, modules := loadModules(lines)
 	initializeConjunctionModules(modules)
 
-	gq := &GlobalQueue{}
+	// the modules return to an earlier state at some point, the pulses of the presses in between
+	// repeat from then on
+	names := sortedKeys(modules)
+	initial := snapshot(modules, names)
+	press := func(state string) string {
+		restore(modules, names, state)
+		(&GlobalQueue{}).Process(modules)
+		return snapshot(modules, names)
+	}
+	mu, lambda, ok := cycle.Find(initial, press, func(state string) string { return state }, presses)
+
+	n := presses
+	if ok {
+		n = min(presses, mu+lambda)
+	}
 
-	for range 1000 {
+	// lows and highs count the pulses after i presses
+	restore(modules, names, initial)
+	gq := &GlobalQueue{}
+	lows, highs := make([]int, n+1), make([]int, n+1)
+	for i := range n {
 		gq.Process(modules)
+		lows[i+1], highs[i+1] = gq.Counters()
 	}
 
-	low, high := gq.Counters()
-	fmt.Printf("Low: %d; High: %d; Product: %d\n", low, high, low*high)
+	if n == presses {
+		return solver.Int(lows[n] * highs[n]), nil
+	}
 
-	return nil
+	cycles, rest := (presses-mu)/lambda, (presses-mu)%lambda
+	low := lows[mu+rest] + cycles*(lows[mu+lambda]-lows[mu])
+	high := highs[mu+rest] + cycles*(highs[mu+lambda]-highs[mu])
+
+	return solver.Int(low * high), nil
+}
+
+// snapshot encodes the states of the flip-flops and the memories of the conjunctions.
+func snapshot(modules map[string]Module, names []string) string {
+	var sb strings.Builder
+	for _, name := range names {
+		switch m := modules[name].(type) {
+		case *FlipFlopModule:
+			sb.WriteByte(pulseByte(m.State))
+		case *ConjunctionModule:
+			for _, input := range sortedKeys(m.memory) {
+				sb.WriteByte(pulseByte(m.memory[input]))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// restore sets the modules to a state encoded by snapshot.
+func restore(modules map[string]Module, names []string, state string) {
+	i := 0
+	for _, name := range names {
+		switch m := modules[name].(type) {
+		case *FlipFlopModule:
+			m.State = state[i] == '1'
+			i++
+		case *ConjunctionModule:
+			for _, input := range sortedKeys(m.memory) {
+				m.memory[input] = state[i] == '1'
+				i++
+			}
+		}
+	}
+}
+
+func pulseByte(p Pulse) byte {
+	if p {
+		return '1'
+	}
+	return '0'
 }
 
 func initializeConjunctionModules(modules map[string]Module) {
@@ -117,12 +180,14 @@ type PendingPulse struct {
 type GlobalQueue struct {
 	lowPulses  int
 	highPulses int
-	queue      []PendingPulse
+	queue      collections.Queue[PendingPulse]
+	// Observe is called with every delivered pulse if set.
+	Observe func(pending PendingPulse)
 }
 
 func (gq *GlobalQueue) Enqueue(pulse Pulse, source string, destinations ...string) {
 	for _, destination := range destinations {
-		gq.queue = append(gq.queue, PendingPulse{
+		gq.queue.PushBack(PendingPulse{
 			Source:      source,
 			Destination: destination,
 			Pulse:       pulse,
@@ -135,27 +200,44 @@ func (gq *GlobalQueue) Counters() (int, int) {
 }
 
 func (gq *GlobalQueue) Process(modules map[string]Module) {
-	gq.queue = append(gq.queue, PendingPulse{
+	gq.Press()
+	for gq.Deliver(modules) {
+	}
+}
+
+// Press queues the low pulse of the button.
+func (gq *GlobalQueue) Press() {
+	gq.queue.PushBack(PendingPulse{
 		Source:      "button",
 		Destination: "broadcaster",
 		Pulse:       Low,
 	})
+}
 
-	for len(gq.queue) > 0 {
-		pending := gq.queue[0]
-		gq.queue = gq.queue[1:]
-		if pending.Pulse {
-			gq.highPulses++
-		} else {
-			gq.lowPulses++
-		}
+// Deliver sends the next pending pulse to its destination. It returns false if no pulse is left.
+func (gq *GlobalQueue) Deliver(modules map[string]Module) bool {
+	if gq.queue.Len() == 0 {
+		return false
+	}
 
-		fmt.Printf("%s -%t-> %s\n", pending.Source, pending.Pulse, pending.Destination)
+	pending := gq.queue.PopFront()
+	if pending.Pulse {
+		gq.highPulses++
+	} else {
+		gq.lowPulses++
+	}
 
-		if module, ok := modules[pending.Destination]; ok {
-			module.Trigger(gq, pending.Source, pending.Pulse)
-		}
+	// fmt.Printf("%s -%t-> %s\n", pending.Source, pending.Pulse, pending.Destination)
+
+	if gq.Observe != nil {
+		gq.Observe(pending)
+	}
+
+	if module, ok := modules[pending.Destination]; ok {
+		module.Trigger(gq, pending.Source, pending.Pulse)
 	}
+
+	return true
 }
 
 type Module interface {
@@ -217,74 +299,246 @@ func (m *BroadcasterModule) Outputs() []string {
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+// task2 counts the button presses until rx receives a low pulse. Pass -v after the input file to
+// print the decomposition of the network and -limit N to change the number of presses simulated
+// if the network isn't made of independent counters.
+func task2(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
+	}
+
+	verbose, limit := false, 10_000_000
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "-v":
+			verbose = true
+		case "-limit":
+			if i+1 >= len(args) {
+				return solver.Answer{}, errors.New("-limit needs a number of presses")
+			}
+			i++
+			if limit, err = strconv.Atoi(args[i]); err != nil {
+				return solver.Answer{}, fmt.Errorf("invalid limit %q: %w", args[i], err)
+			}
+		}
 	}
 
 	_, modules := loadModules(lines)
 	initializeConjunctionModules(modules)
 
-	gq := &GlobalQueue2{}
+	d, err := Decompose(modules, "rx")
+	if err == nil {
+		err = d.Measure(lines, 1<<16)
+	}
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "falling back to simulation: %s\n", err)
+		}
 
-	// state, bits := moduleState(names, modules)
-	// fmt.Printf("%d (%048b)\n", len(state), bits)
+		presses, ok := simulate(modules, "rx", limit)
+		if !ok {
+			return solver.Answer{}, fmt.Errorf("rx didn't receive a low pulse within %d presses", limit)
+		}
+		return solver.Int(presses), nil
+	}
 
-	counter := 0
-	for !gq.Check() {
-		counter++
+	if verbose {
+		fmt.Fprint(os.Stderr, d)
+	}
 
-		gq.Process(counter, modules)
+	presses, ok := d.Presses()
+	if !ok {
+		return solver.Answer{}, errors.New("the counters never fire in the same press")
+	}
 
-		// bits, count := gq.Number()
-		// fmt.Printf("Bits: % 8d (%064b)\n", bits, bits)
-		// fmt.Printf("                %s%s\n", strings.Repeat(" ", 64-count), strings.Repeat("-", count))
+	return solver.BigInt(presses), nil
+}
 
-		// _, bits := moduleState(names, modules)
-		// fmt.Printf("Iterated %d times (%048b)\r", counter, bits)
+type Enqueuer interface {
+	Enqueue(pulse Pulse, source string, destinations ...string)
+}
+
+// simulate presses the button until the destination receives a low pulse, at most limit times.
+func simulate(modules map[string]Module, destination string, limit int) (int, bool) {
+	found := false
+	gq := &GlobalQueue{
+		Observe: func(pending PendingPulse) {
+			if pending.Destination == destination && pending.Pulse == Low {
+				found = true
+			}
+		},
 	}
 
-	fmt.Printf("Counter: %d\n", counter)
+	for presses := 1; presses <= limit; presses++ {
+		gq.Process(modules)
+		if found {
+			return presses, true
+		}
+	}
 
-	return nil
+	return 0, false
 }
 
-type Enqueuer interface {
-	Enqueue(pulse Pulse, source string, destinations ...string)
+// Counter is a sub-network which only receives pulses from the broadcaster and sends a high pulse
+// to the final conjunction every Period presses, the first time after Offset presses.
+type Counter struct {
+	Output  string
+	Modules []string
+	Offset  int
+	Period  int
 }
 
-type GlobalQueue2 struct {
-	rxCount int
-	rxBits  uint64
-	queue   []PendingPulse
+// Decomposition splits the network into the counters feeding the final conjunction, which sends
+// a low pulse to the destination once all counters fire in the same press.
+type Decomposition struct {
+	Final    string
+	Counters []Counter
 }
 
-func moduleState(names []string, modules map[string]Module) ([]Pulse, uint64) {
-	states := make([]Pulse, 0, len(modules))
-	bits := uint64(0)
-	for _, name := range names {
-		module := modules[name]
+// Decompose recognizes a network where the destination is fed by a single conjunction, whose
+// inputs are driven by disjoint sub-networks.
+func Decompose(modules map[string]Module, destination string) (*Decomposition, error) {
+	network := Network(modules)
 
-		switch t := module.(type) {
-		case *FlipFlopModule:
-			states = append(states, t.State)
-			bits <<= 1
-			if t.State {
-				bits |= 1
+	feeders := network.Inputs(destination)
+	if len(feeders) != 1 {
+		return nil, fmt.Errorf("%s has %d inputs, expected one", destination, len(feeders))
+	}
+	if _, ok := modules[feeders[0]].(*ConjunctionModule); !ok {
+		return nil, fmt.Errorf("%s is not fed by a conjunction", destination)
+	}
+
+	d := &Decomposition{Final: feeders[0]}
+	owner := make(map[string]string)
+
+	for _, output := range network.Inputs(d.Final) {
+		c := Counter{Output: output}
+
+		// collect everything upstream of the output up to the broadcaster
+		seen := map[string]bool{output: true}
+		queue := []string{output}
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			c.Modules = append(c.Modules, name)
+
+			for _, input := range network.Inputs(name) {
+				if input == "broadcaster" || seen[input] {
+					continue
+				}
+				if input == d.Final {
+					return nil, fmt.Errorf("%s feeds back into the counter of %s", d.Final, output)
+				}
+				seen[input] = true
+				queue = append(queue, input)
 			}
-		case *ConjunctionModule:
-			continue
-			if len(t.memory) <= 1 {
-				continue
+		}
+
+		for _, name := range c.Modules {
+			if other, ok := owner[name]; ok {
+				return nil, fmt.Errorf("%s is shared by the counters of %s and %s", name, other, output)
+			}
+			owner[name] = output
+		}
+
+		sort.Strings(c.Modules)
+		d.Counters = append(d.Counters, c)
+	}
+
+	if len(d.Counters) == 0 {
+		return nil, fmt.Errorf("%s has no inputs", d.Final)
+	}
+
+	return d, nil
+}
+
+// Measure simulates a fresh copy of the network and records when each counter sends a high pulse
+// to the final conjunction. It returns an error if a counter doesn't fire periodically within
+// limit presses.
+func (d *Decomposition) Measure(lines []string, limit int) error {
+	_, modules := loadModules(lines)
+	initializeConjunctionModules(modules)
+
+	// the first three presses in which each counter fires, the third verifies the period
+	fired := make(map[string][]int)
+	presses := 0
+
+	gq := &GlobalQueue{
+		Observe: func(pending PendingPulse) {
+			if pending.Destination != d.Final || pending.Pulse != High {
+				return
 			}
-			inputNames := sortedKeys(t.memory)
-			for _, name := range inputNames {
-				states = append(states, t.memory[name])
+			times := fired[pending.Source]
+			if len(times) < 3 && (len(times) == 0 || times[len(times)-1] != presses) {
+				fired[pending.Source] = append(times, presses)
 			}
+		},
+	}
+
+	done := func() bool {
+		for _, c := range d.Counters {
+			if len(fired[c.Output]) < 3 {
+				return false
+			}
+		}
+		return true
+	}
+
+	for presses = 1; presses <= limit && !done(); presses++ {
+		gq.Process(modules)
+	}
+
+	for i := range d.Counters {
+		c := &d.Counters[i]
+		times := fired[c.Output]
+		if len(times) < 3 {
+			return fmt.Errorf("%s fired %d times in %d presses", c.Output, len(times), limit)
 		}
+
+		c.Offset, c.Period = times[0], times[1]-times[0]
+		if times[2]-times[1] != c.Period {
+			return fmt.Errorf("%s fires irregularly after %v presses", c.Output, times)
+		}
+	}
+
+	return nil
+}
+
+// Presses combines the periods of the counters with the Chinese remainder theorem and returns the
+// first press in which all of them fire. The combined period of large counters doesn't necessarily
+// fit into an int, so the result is a big.Int.
+func (d *Decomposition) Presses() (*big.Int, bool) {
+	residues := make([]*big.Int, len(d.Counters))
+	moduli := make([]*big.Int, len(d.Counters))
+	first := 0
+	for i, c := range d.Counters {
+		residues[i], moduli[i] = big.NewInt(int64(c.Offset)), big.NewInt(int64(c.Period))
+		first = max(first, c.Offset)
+	}
+
+	r, m, ok := mathx.CRTBig(residues, moduli)
+	if !ok {
+		return nil, false
+	}
+
+	// the counters only start firing after their offsets
+	if missing := new(big.Int).Sub(big.NewInt(int64(first)), r); missing.Sign() > 0 {
+		missing.Add(missing, m)
+		missing.Sub(missing, big.NewInt(1))
+		r.Add(r, missing.Mul(missing.Quo(missing, m), m))
+	}
+
+	return r, true
+}
+
+func (d *Decomposition) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%d counters feed %s:\n", len(d.Counters), d.Final)
+	for _, c := range d.Counters {
+		fmt.Fprintf(b, "  %s: %d modules, first fires after %d presses, period %d\n", c.Output, len(c.Modules), c.Offset, c.Period)
 	}
-	return states, bits
+	return b.String()
 }
 
 func sortedKeys[T any](m map[string]T) []string {
@@ -296,64 +550,179 @@ func sortedKeys[T any](m map[string]T) []string {
 	return keys
 }
 
-func (gq *GlobalQueue2) Enqueue(pulse Pulse, source string, destinations ...string) {
-	for _, destination := range destinations {
-		gq.queue = append(gq.queue, PendingPulse{
-			Source:      source,
-			Destination: destination,
-			Pulse:       pulse,
-		})
+type Network map[string]Module
+
+func (n Network) Graph() *dot.Graph {
+	g := dot.New("modules", true)
+	g.Node("button", "button").Shape = "plaintext"
+	g.Edge("button", "broadcaster", "")
+
+	for _, name := range sortedKeys(n) {
+		node := g.Node(name, name)
+
+		switch n[name].(type) {
+		case *FlipFlopModule:
+			node.Label = "%" + name
+			node.Shape = "box"
+		case *ConjunctionModule:
+			node.Label = "&" + name
+			node.Shape = "diamond"
+		}
+
+		for _, output := range n[name].Outputs() {
+			// untyped modules like rx only receive pulses
+			if _, ok := n[output]; !ok {
+				g.Node(output, output).Shape = "plaintext"
+			}
+
+			g.Edge(name, output, "")
+		}
 	}
+
+	return g
 }
 
-func (gq *GlobalQueue2) Check() bool {
-	return gq.rxCount == 1 && gq.rxBits == 0
+// Inputs returns the names of the modules sending pulses to the destination.
+func (n Network) Inputs(destination string) []string {
+	var inputs []string
+	for _, name := range sortedKeys(n) {
+		if slices.Contains(n[name].Outputs(), destination) {
+			inputs = append(inputs, name)
+		}
+	}
+	return inputs
 }
 
-func (gq *GlobalQueue2) Number() (uint64, int) {
-	return gq.rxBits, gq.rxCount
+// graph exports the module network, rx and the modules feeding it are highlighted.
+func graph(args []string) (dot.Grapher, error) {
+	lines, err := parse.ReadLines(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	_, modules := loadModules(lines)
+	network := Network(modules)
+	g := network.Graph()
+
+	highlight := []string{"rx"}
+	for _, input := range network.Inputs("rx") {
+		highlight = append(highlight, input)
+		highlight = append(highlight, network.Inputs(input)...)
+	}
+	g.HighlightNodes(highlight...)
+
+	return g, nil
 }
 
-func (gq *GlobalQueue2) Process(counter int, modules map[string]Module) {
-	gq.queue = append(gq.queue, PendingPulse{
-		Source:      "button",
-		Destination: "broadcaster",
-		Pulse:       Low,
-	})
-	gq.rxCount = 0
-	gq.rxBits = 0
-
-	for len(gq.queue) > 0 {
-		pending := gq.queue[0]
-		copy(gq.queue, gq.queue[1:])
-		gq.queue = gq.queue[:len(gq.queue)-1]
-
-		// fmt.Printf("%s -%t-> %s\n", pending.Source, pending.Pulse, pending.Destination)
-
-		if pending.Pulse == Low {
-			switch pending.Destination {
-			case "hz":
-				fmt.Println(counter, "Section HP sends High!")
-				panic("stop")
-			case "pv":
-				fmt.Println(counter, "Section QN sends High!")
-			case "qh":
-				fmt.Println(counter, "Section XV sends High!")
-			case "xm":
-				fmt.Println(counter, "Section ZB sends High!")
-			}
+///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Simulator delivers one pulse per step in the debugger, the button is pressed whenever no pulse
+// is pending. The position is the number of button presses.
+type Simulator struct {
+	lines   []string
+	modules map[string]Module
+	gq      *GlobalQueue
+	presses int
+	limit   int
+}
+
+func (s *Simulator) Step() bool {
+	if s.gq.queue.Len() == 0 {
+		if s.presses >= s.limit {
+			return false
 		}
+		s.presses++
+		s.gq.Press()
+	}
+
+	return s.gq.Deliver(s.modules)
+}
+
+func (s *Simulator) Position() int {
+	return s.presses
+}
+
+func (s *Simulator) Next() string {
+	if s.gq.queue.Len() == 0 {
+		if s.presses >= s.limit {
+			return "halted"
+		}
+		return "button -low-> broadcaster"
+	}
+
+	pending := s.gq.queue.Peek()
+	pulse := "low"
+	if pending.Pulse {
+		pulse = "high"
+	}
+	return fmt.Sprintf("%s -%s-> %s", pending.Source, pulse, pending.Destination)
+}
 
-		if pending.Destination == "rx" {
-			gq.rxCount++
-			gq.rxBits <<= 1
-			if pending.Pulse == High {
-				gq.rxBits |= 1
+// State returns the pulse counters and the states of the flip-flops.
+func (s *Simulator) State() []debug.Register {
+	low, high := s.gq.Counters()
+	regs := []debug.Register{
+		{Name: "presses", Value: strconv.Itoa(s.presses)},
+		{Name: "low", Value: strconv.Itoa(low)},
+		{Name: "high", Value: strconv.Itoa(high)},
+	}
+
+	for _, name := range sortedKeys(s.modules) {
+		if ff, ok := s.modules[name].(*FlipFlopModule); ok {
+			value := "0"
+			if ff.State {
+				value = "1"
 			}
+			regs = append(regs, debug.Register{Name: name, Value: value})
+		}
+	}
+
+	return regs
+}
+
+// Restart reloads the modules. Flip-flops can be switched on by name and "presses" sets the number
+// of button presses before the simulation halts.
+func (s *Simulator) Restart(values map[string]int) error {
+	_, modules := loadModules(s.lines)
+	initializeConjunctionModules(modules)
+
+	for name, v := range values {
+		if name == "presses" {
+			s.limit = v
+			continue
+		}
+
+		ff, ok := modules[name].(*FlipFlopModule)
+		if !ok {
+			return fmt.Errorf("%s is not a flip-flop", name)
 		}
+		ff.State = v != 0
+	}
+
+	s.modules = modules
+	s.gq = &GlobalQueue{}
+	s.presses = 0
+
+	return nil
+}
 
-		if module, ok := modules[pending.Destination]; ok {
-			module.Trigger(gq, pending.Source, pending.Pulse)
+// debugNetwork simulates the modules in the debugger. The number of button presses defaults to
+// 1000 and can be changed by an argument after the input file.
+func debugNetwork(args []string) (debug.Steppable, error) {
+	lines, err := parse.ReadLines(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Simulator{lines: lines, limit: 1000}
+	if len(args) > 1 {
+		if s.limit, err = strconv.Atoi(args[1]); err != nil {
+			return nil, fmt.Errorf("invalid number of presses %q: %w", args[1], err)
 		}
 	}
+
+	if err = s.Restart(nil); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
@@ -0,0 +1,47 @@
+package day20
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPresses(t *testing.T) {
+	// the counters of real inputs fire first after one period, the periods are primes
+	primes := []int{3733, 3761, 3767, 3779, 3793, 3797}
+	product := big.NewInt(1)
+	var large []Counter
+	for _, p := range primes {
+		large = append(large, Counter{Offset: p, Period: p})
+		product.Mul(product, big.NewInt(int64(p)))
+	}
+
+	tests := []struct {
+		name     string
+		counters []Counter
+		want     *big.Int
+	}{
+		{"coprime", []Counter{{Offset: 3, Period: 4}, {Offset: 2, Period: 5}}, big.NewInt(7)},
+		{"common factor", []Counter{{Offset: 3, Period: 4}, {Offset: 1, Period: 6}}, big.NewInt(7)},
+		{"after the offsets", []Counter{{Offset: 10, Period: 3}, {Offset: 1, Period: 4}}, big.NewInt(13)},
+		{"never together", []Counter{{Offset: 4, Period: 4}, {Offset: 1, Period: 2}}, nil},
+		{"overflow", large, product},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Decomposition{Counters: tt.counters}
+
+			got, ok := d.Presses()
+			if tt.want == nil {
+				if ok {
+					t.Errorf("Presses() = %s, want no solution", got)
+				}
+				return
+			}
+
+			if !ok || got.Cmp(tt.want) != 0 {
+				t.Errorf("Presses() = %s, %t, want %s", got, ok, tt.want)
+			}
+		})
+	}
+}
@@ -1,357 +1,192 @@
-package main
+package day21
 
 import (
-	"bufio"
-	"encoding/binary"
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
-	"time"
-)
-
-type empty = struct{}
-
-func main() {
-	// f, err := os.Create("profile.pprof")
-	// if err != nil {
-	// 	fmt.Printf("Error: %s\n", err)
-	// 	os.Exit(1)
-	// }
-	// defer f.Close()
-
-	// pprof.StartCPUProfile(f)
-	// defer pprof.StopCPUProfile()
-
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"strconv"
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/lib/collections"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/grid"
+	"github.com/noxer/aoc/lib/mathx"
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2023, 21, task1, task2)
 }
 
-var directions = []Pos{
+var directions = []geom.Vec{
 	{X: +1}, // right
 	{X: -1}, // left
 	{Y: +1}, // down
 	{Y: -1}, // up
 }
 
-type Pos struct {
-	X, Y int
-}
-
-func (p Pos) Hash() int {
-	buf := make([]byte, 0, 8)
-	buf = binary.AppendVarint(buf, int64(p.X))
-	buf = binary.AppendVarint(buf, int64(p.Y))
-
-	return int(binary.LittleEndian.Uint64(buf))
-}
-
-func (p Pos) Add(o Pos) Pos {
-	return Pos{p.X + o.X, p.Y + o.Y}
-}
-
-func (p Pos) ID() uint64 {
-	return uint64(p.X) + uint64(p.Y)
+// Garden is the map of garden plots and rocks. If it is tiled, it repeats infinitely in all
+// directions.
+type Garden struct {
+	grid.Grid[byte]
+	Start geom.Vec
+	Tiled bool
 }
 
-type Map map[Pos]byte
-
-func (m Map) CanGo(p Pos) bool {
-	_, ok := m[p]
-	return ok
-}
-
-func loadMap(name string) (Map, Pos, error) {
-	m := make(Map)
-
-	f, err := os.Open(name)
+func loadGarden(name string) (Garden, error) {
+	g, err := grid.Read(name)
 	if err != nil {
-		return nil, Pos{}, err
-	}
-	defer f.Close()
-
-	s := bufio.NewScanner(f)
-
-	start := Pos{}
-	y := 0
-	for s.Scan() {
-		for x, b := range s.Bytes() {
-			switch b {
-			case 'S':
-				start = Pos{x, y}
-				fallthrough
-			case '.':
-				m[Pos{x, y}] = '.'
-			}
-		}
-
-		y++
+		return Garden{}, err
 	}
 
-	return m, start, nil
-}
-
-func move(m Map, positions map[Pos]struct{}) map[Pos]struct{} {
-	newPositions := make(map[Pos]struct{})
-
-	for pos := range positions {
-		for _, dir := range directions {
-			newPos := pos.Add(dir)
-			if m.CanGo(newPos) {
-				newPositions[newPos] = struct{}{}
-			}
-		}
+	start, ok := grid.Find(g, 'S')
+	if !ok {
+		return Garden{}, errors.New("there is no start")
 	}
 
-	return newPositions
+	return Garden{Grid: g, Start: start}, nil
 }
 
-func task1(args []string) error {
-	m, s, err := loadMap(args[0])
-	if err != nil {
-		return err
-	}
-
-	positions := map[Pos]struct{}{
-		s: {},
-	}
-
-	for range 64 {
-		positions = move(m, positions)
+func (g Garden) CanGo(p geom.Vec) bool {
+	if g.Tiled {
+		p = geom.Vec{X: mathx.Mod(p.X, g.Width()), Y: mathx.Mod(p.Y, g.Height())}
 	}
 
-	fmt.Printf("Count: %d\n", len(positions))
-
-	return nil
-}
-
-///////////////////////////////////////////////////////////////////////////////////////////////////
-///////////////////////////////////////////////////////////////////////////////////////////////////
-///////////////////////////////////////////////////////////////////////////////////////////////////
-
-type Set[T comparable] map[T]struct{}
-
-func (s Set[T]) Put(v T) {
-	s[v] = struct{}{}
+	b, ok := g.Get(p)
+	return ok && b != '#'
 }
 
-func (s Set[T]) Merge(o Set[T]) {
-	for v := range o {
-		s[v] = struct{}{}
-	}
-}
+// Distances searches breadth first from the start and returns the number of plots at each
+// distance up to the limit.
+func (g Garden) Distances(limit int) []int {
+	counts := make([]int, limit+1)
+	dist := map[geom.Vec]int{g.Start: 0}
 
-func (s Set[T]) MergeWith(o Set[T], f func(T) T) {
-	for v := range o {
-		s[f(v)] = struct{}{}
-	}
-}
-
-// type Set[T comparable] []T
-
-// func (s *Set[T]) Has(v T) bool {
-// 	for _, t := range *s {
-// 		if t == v {
-// 			return true
-// 		}
-// 	}
-// 	return false
-// }
-
-// func (s *Set[T]) Put(v T) {
-// 	if !s.Has(v) {
-// 		*s = append(*s, v)
-// 	}
-// }
-
-// func (s *Set[T]) Merge(o *Set[T]) {
-// 	// *s = slices.Grow(*s, len(*o))
-// 	for _, t := range *o {
-// 		s.Put(t)
-// 	}
-// }
-
-// func (s *Set[T]) MergeWith(o *Set[T], f func(T) T) {
-// 	// *s = slices.Grow(*s, len(*o))
-// 	for _, t := range *o {
-// 		s.Put(f(t))
-// 	}
-// }
-
-type Map2 struct {
-	m map[Pos]Set[Pos]
-	s Pos
-}
-
-func (m Map2) Move(p Pos, result Map2) {
-	s := m.m[p]
-	if len(s) == 0 {
-		return
-	}
-
-	for _, dir := range directions {
-		newPos := p.Add(dir)
-		if !m.CanGo(newPos) {
+	queue := collections.NewQueue(64, g.Start)
+	for p := range queue.Drain() {
+		d := dist[p]
+		counts[d]++
+		if d == limit {
 			continue
 		}
 
-		offset := m.CheckNextGarden(newPos)
-		target := result.m[m.Lep(newPos)]
-		target.MergeWith(s, func(p Pos) Pos {
-			return p.Add(offset)
-		})
-	}
-}
-
-func (m Map2) Lep(p Pos) Pos {
-	p.X %= m.s.X
-	p.Y %= m.s.Y
+		for _, dir := range directions {
+			next := p.Add(dir)
+			if _, ok := dist[next]; ok || !g.CanGo(next) {
+				continue
+			}
 
-	if p.X < 0 {
-		p.X += m.s.X
-	}
-	if p.Y < 0 {
-		p.Y += m.s.Y
+			dist[next] = d + 1
+			queue.Push(next)
+		}
 	}
 
-	return p
+	return counts
 }
 
-func (m Map2) CheckNextGarden(p Pos) Pos {
-	dir := Pos{}
-
-	if p.X < 0 {
-		dir.X = -1
-	} else if p.X >= m.s.X {
-		dir.X = +1
-	}
-
-	if p.Y < 0 {
-		dir.Y = -1
-	} else if p.Y >= m.s.Y {
-		dir.Y = +1
+// Reachable returns the number of plots the elf can end on after exactly the given number of
+// steps. These are the plots at most that far away with a distance of the same parity, as the elf
+// can step back and forth to waste two steps.
+func Reachable(counts []int, steps int) int {
+	sum := 0
+	for d := steps % 2; d <= steps && d < len(counts); d += 2 {
+		sum += counts[d]
 	}
-
-	return dir
+	return sum
 }
 
-func (m Map2) Reset() {
-	for p := range m.m {
-		m.m[p] = make(Set[Pos])
+// parseSteps reads the optional number of steps after the input file.
+func parseSteps(args []string, steps int) (int, error) {
+	if len(args) < 2 {
+		return steps, nil
 	}
+	return strconv.Atoi(args[1])
 }
 
-func (m Map2) EmptyCopy() Map2 {
-	c := Map2{
-		m: make(map[Pos]Set[Pos], len(m.m)),
-		s: m.s,
+// task1 counts the plots reachable in 64 steps on the map without tiling. The number of steps can
+// be passed after the input file.
+func task1(args []string) (solver.Answer, error) {
+	g, err := loadGarden(args[0])
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	for p := range m.m {
-		c.m[p] = make(Set[Pos])
+	steps, err := parseSteps(args, 64)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	return c
-}
-
-func (m Map2) Count() uint64 {
-	sum := uint64(0)
-	for _, s := range m.m {
-		sum += uint64(len(s))
-	}
-	return sum
+	return solver.Int(Reachable(g.Distances(steps), steps)), nil
 }
 
-func (m Map2) CanGo(p Pos) bool {
-	p.X %= m.s.X
-	p.Y %= m.s.Y
-
-	if p.X < 0 {
-		p.X += m.s.X
-	}
-	if p.Y < 0 {
-		p.Y += m.s.Y
-	}
+///////////////////////////////////////////////////////////////////////////////////////////////////
 
-	_, ok := m.m[p]
-	return ok
-}
+// samples is the number of step counts the fit is based on, one more than needed for a quadratic
+// so the fit can be verified.
+const samples = 4
 
-func loadMap2(name string) (m Map2, err error) {
-	m.m = make(map[Pos]Set[Pos])
+// maxShift is the number of periods skipped at most while looking for a fit.
+const maxShift = 8
 
-	f, err := os.Open(name)
+// task2 counts the plots reachable in 26501365 steps on the tiled map. The number of plots grows
+// quadratically in steps of the map size, so it is extrapolated from a few step counts with the
+// same remainder. The number of steps can be passed after the input file, -check compares the
+// fit against a breadth first search for the next step counts.
+func task2(args []string) (solver.Answer, error) {
+	g, err := loadGarden(args[0])
 	if err != nil {
-		return Map2{}, err
+		return solver.Answer{}, err
 	}
-	defer f.Close()
-
-	s := bufio.NewScanner(f)
+	g.Tiled = true
 
-	y := 0
-	for s.Scan() {
-		for x, b := range s.Bytes() {
-			m.s.X = max(m.s.X, x)
-
-			switch b {
-			case 'S':
-				m.m[Pos{x, y}] = Set[Pos]{Pos{}: empty{}}
-			case '.':
-				m.m[Pos{x, y}] = Set[Pos]{}
-			}
-		}
-
-		y++
+	check := len(args) > 1 && args[len(args)-1] == "-check"
+	if check {
+		args = args[:len(args)-1]
 	}
 
-	m.s.X++
-	m.s.Y = y
-	return m, nil
-}
-
-func move2(m, c Map2) {
-	for p := range m.m {
-		m.Move(p, c)
+	steps, err := parseSteps(args, 26501365)
+	if err != nil {
+		return solver.Answer{}, err
 	}
-}
 
-func task2(args []string) error {
-	m, err := loadMap2(args[0])
-	if err != nil {
-		return err
+	period := g.Width()
+	if g.Height() != period {
+		return solver.Answer{}, errors.New("the map isn't square")
 	}
 
-	c := m.EmptyCopy()
+	// the plots at short distances don't follow the pattern yet, skip periods until the samples
+	// fit a quadratic
+	base := steps % period
+	counts, ys := []int(nil), make([]int, samples)
+	for shift := 0; ; shift++ {
+		if steps <= base+(samples-1)*period {
+			return solver.Int(Reachable(g.Distances(steps), steps)), nil
+		}
+		if shift == maxShift {
+			return solver.Answer{}, errors.New("the plots don't grow quadratically")
+		}
 
-	start := time.Now()
-	for i := range 500 {
-		fmt.Printf("Computing iteration %d...", i)
-		loopStart := time.Now()
-		move2(m, c)
-		m, c = c, m
-		// c.Reset()
-		fmt.Println("ok |", time.Since(loopStart))
+		counts = g.Distances(base + (samples+1)*period)
+		for i := range ys {
+			ys[i] = Reachable(counts, base+i*period)
+		}
+		if mathx.Degree(ys) <= 2 {
+			break
+		}
+
+		base += period
 	}
-	duration := time.Since(start)
 
-	fmt.Printf("Count: %d (%s)\n", m.Count(), duration)
+	if check {
+		for i := samples; i <= samples+1; i++ {
+			n := base + i*period
+			fit, brute := mathx.Extrapolate(ys, i), Reachable(counts, n)
+			fmt.Fprintf(os.Stderr, "%d steps: fit %s, search %d\n", n, fit, brute)
+			if fit.Cmp(big.NewInt(int64(brute))) != 0 {
+				return solver.Answer{}, fmt.Errorf("the fit is wrong for %d steps", n)
+			}
+		}
+	}
 
-	return nil
+	return solver.BigInt(mathx.Extrapolate(ys, (steps-base)/period)), nil
 }
@@ -0,0 +1,138 @@
+package day22
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Tower is a stack of settled bricks. The bricks are ordered from bottom to top, so every brick
+// comes after the bricks it rests on.
+type Tower struct {
+	Bricks []Brick
+	Below  [][]int // the bricks each brick rests on, empty for bricks on the ground
+	Above  [][]int // the bricks resting on each brick
+}
+
+// Settle lets the bricks fall until they rest on the ground or on other bricks. The bricks are
+// dropped from bottom to top, each one in a single step onto the highest column below it.
+func Settle(bricks []Brick) *Tower {
+	bricks = slices.Clone(bricks)
+	slices.SortStableFunc(bricks, func(a, b Brick) int {
+		return a.Start.Z - b.Start.Z
+	})
+
+	width, depth := 0, 0
+	for _, b := range bricks {
+		width, depth = max(width, b.End.X+1), max(depth, b.End.Y+1)
+	}
+
+	// the height of each column and the brick on top of it
+	height := make([]int, width*depth)
+	top := make([]int, width*depth)
+
+	t := &Tower{
+		Bricks: bricks,
+		Below:  make([][]int, len(bricks)),
+		Above:  make([][]int, len(bricks)),
+	}
+
+	for i, b := range bricks {
+		rest := 0
+		for c := range b.columns(depth) {
+			rest = max(rest, height[c])
+		}
+
+		for c := range b.columns(depth) {
+			if rest > 0 && height[c] == rest && !slices.Contains(t.Below[i], top[c]) {
+				t.Below[i] = append(t.Below[i], top[c])
+				t.Above[top[c]] = append(t.Above[top[c]], i)
+			}
+		}
+
+		b.End.Z -= b.Start.Z - rest - 1
+		b.Start.Z = rest + 1
+		for c := range b.columns(depth) {
+			height[c], top[c] = b.End.Z, i
+		}
+		bricks[i] = b
+	}
+
+	return t
+}
+
+// Dominators returns the immediate dominator of every brick in the support graph rooted at the
+// ground: the highest brick whose removal makes the brick fall, -1 if only the ground holds it.
+func (t *Tower) Dominators() []int {
+	n := len(t.Bricks)
+	ground := n
+
+	// up[k][i] is the 2^k-th dominator above the ground of brick i, the ground is its own
+	// dominator
+	levels := bits.Len(uint(n)) + 1
+	up := make([][]int, levels)
+	for k := range up {
+		up[k] = make([]int, n+1)
+		up[k][ground] = ground
+	}
+	depth := make([]int, n+1)
+
+	lca := func(a, b int) int {
+		if depth[a] < depth[b] {
+			a, b = b, a
+		}
+		for k := levels - 1; k >= 0; k-- {
+			if depth[a]-1<<k >= depth[b] {
+				a = up[k][a]
+			}
+		}
+		if a == b {
+			return a
+		}
+		for k := levels - 1; k >= 0; k-- {
+			if up[k][a] != up[k][b] {
+				a, b = up[k][a], up[k][b]
+			}
+		}
+		return up[0][a]
+	}
+
+	// the bricks are in topological order, so the dominators of all bricks below are known
+	idom := make([]int, n)
+	for i, below := range t.Below {
+		d := ground
+		if len(below) > 0 {
+			d = below[0]
+			for _, j := range below[1:] {
+				d = lca(d, j)
+			}
+		}
+
+		depth[i] = depth[d] + 1
+		up[0][i] = d
+		for k := 1; k < levels; k++ {
+			up[k][i] = up[k-1][up[k-1][i]]
+		}
+
+		idom[i] = d
+		if d == ground {
+			idom[i] = -1
+		}
+	}
+
+	return idom
+}
+
+// Falls returns the number of other bricks falling if a brick is removed, for every brick. These
+// are the bricks dominated by it.
+func (t *Tower) Falls() []int {
+	idom := t.Dominators()
+
+	falls := make([]int, len(idom))
+	for i := len(idom) - 1; i >= 0; i-- {
+		if d := idom[i]; d >= 0 {
+			falls[d] += falls[i] + 1
+		}
+	}
+
+	return falls
+}
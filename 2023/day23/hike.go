@@ -0,0 +1,183 @@
+package day23
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// Trail leads from a junction to the junction with index To.
+type Trail struct {
+	To     int
+	Length int
+}
+
+// Junctions is the compressed maze with the junctions numbered from 0, so the visited junctions
+// of a hike fit into the bits of an uint64.
+type Junctions struct {
+	Trails     [][]Trail
+	Start, End int
+
+	ids []int // the node IDs of the junctions
+}
+
+// NewJunctions indexes the nodes of the edges. It fails for mazes with more than 64 junctions.
+func NewJunctions(edges []Edge) (*Junctions, error) {
+	j := &Junctions{}
+	index := make(map[int]int)
+	lookup := func(id int) int {
+		i, ok := index[id]
+		if !ok {
+			i = len(j.ids)
+			index[id] = i
+			j.ids = append(j.ids, id)
+			j.Trails = append(j.Trails, nil)
+		}
+		return i
+	}
+
+	j.Start = lookup(StartID)
+	for _, edge := range edges {
+		from, to := lookup(edge.Start), lookup(edge.End)
+		j.Trails[from] = append(j.Trails[from], Trail{To: to, Length: edge.Length})
+	}
+
+	if len(j.ids) > 64 {
+		return nil, errors.New("too many junctions for a bitmask")
+	}
+
+	end, ok := index[EndID]
+	if !ok {
+		return nil, errors.New("the exit isn't connected to the start")
+	}
+	j.End = end
+
+	// if only one junction leads to the exit, a hike which doesn't take the exit there can't
+	// reach it anymore
+	var last []int
+	for i, trails := range j.Trails {
+		if slices.ContainsFunc(trails, j.toEnd) {
+			last = append(last, i)
+		}
+	}
+	if len(last) == 1 {
+		j.Trails[last[0]] = slices.DeleteFunc(j.Trails[last[0]], func(t Trail) bool {
+			return !j.toEnd(t)
+		})
+	}
+
+	return j, nil
+}
+
+func (j *Junctions) toEnd(t Trail) bool {
+	return t.To == j.End
+}
+
+// hike is a partial path from the start, path holds the indices of the visited junctions.
+type hike struct {
+	node    int
+	visited uint64
+	length  int
+	path    []int
+}
+
+// Longest returns the length of the longest hike from the start to the exit not visiting a
+// junction twice, and the node IDs along it. With parallel set, the hikes are split up after the
+// first junctions and searched concurrently.
+func (j *Junctions) Longest(parallel bool) (int, []int, error) {
+	start := hike{node: j.Start, visited: 1 << j.Start, path: []int{j.Start}}
+
+	var best hike
+	if parallel {
+		best = j.searchParallel(start)
+	} else {
+		best = hike{length: -1}
+		j.walk(start, &best)
+	}
+
+	if best.length < 0 {
+		return 0, nil, errors.New("there is no hike to the exit")
+	}
+
+	ids := make([]int, len(best.path))
+	for i, n := range best.path {
+		ids[i] = j.ids[n]
+	}
+	return best.length, ids, nil
+}
+
+// walk searches all hikes continuing h depth first and stores the longest one in best.
+func (j *Junctions) walk(h hike, best *hike) {
+	if h.node == j.End {
+		if h.length > best.length {
+			*best = h
+			best.path = slices.Clone(h.path)
+		}
+		return
+	}
+
+	for _, t := range j.Trails[h.node] {
+		if h.visited&(1<<t.To) != 0 {
+			continue
+		}
+
+		j.walk(hike{
+			node:    t.To,
+			visited: h.visited | 1<<t.To,
+			length:  h.length + t.Length,
+			path:    append(h.path, t.To),
+		}, best)
+	}
+}
+
+// searchParallel expands the hikes breadth first until there is enough work for all CPUs, then
+// continues every hike in its own goroutine.
+func (j *Junctions) searchParallel(start hike) hike {
+	best := hike{length: -1}
+
+	hikes := []hike{start}
+	for len(hikes) > 0 && len(hikes) < 8*runtime.GOMAXPROCS(0) {
+		var next []hike
+		for _, h := range hikes {
+			if h.node == j.End {
+				j.walk(h, &best)
+				continue
+			}
+
+			for _, t := range j.Trails[h.node] {
+				if h.visited&(1<<t.To) != 0 {
+					continue
+				}
+
+				next = append(next, hike{
+					node:    t.To,
+					visited: h.visited | 1<<t.To,
+					length:  h.length + t.Length,
+					path:    append(slices.Clip(h.path), t.To),
+				})
+			}
+		}
+		hikes = next
+	}
+
+	results := make([]hike, len(hikes))
+	wg := sync.WaitGroup{}
+	for i, h := range hikes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = hike{length: -1}
+			j.walk(h, &results[i])
+		}()
+	}
+	wg.Wait()
+
+	// ties go to the hike found first, so the result doesn't depend on the scheduling
+	for _, r := range results {
+		if r.length > best.length {
+			best = r
+		}
+	}
+	return best
+}
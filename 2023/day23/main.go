@@ -1,13 +1,16 @@
-package main
+package day23
 
 import (
 	"fmt"
 	"math/bits"
 	"os"
-	"slices"
+	"strconv"
 	"strings"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/dot"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
 const (
@@ -15,119 +18,54 @@ const (
 	EndID   = -1
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2023, 23, task1, task2)
+	solver.RegisterGraph(2023, 23, graph)
 }
 
-func task1(args []string) error {
-	m, err := utils.ReadLines(args[0])
+// task1 finds the longest hike down the slopes. Pass -v after the input file to print the
+// junctions along the hike.
+func task1(args []string) (solver.Answer, error) {
+	m, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	edges := generateGraph(m)
-	fmt.Println(edges)
+	return longestHike(generateGraph(m), len(args) > 1 && args[1] == "-v")
+}
 
-	paths := []Path{
-		{
-			Current: StartID,
-			Length:  0,
-			Visited: make(Set[int]),
-		},
+// longestHike searches the longest hike through the graph and optionally prints it to stderr.
+func longestHike(edges []Edge, verbose bool) (solver.Answer, error) {
+	j, err := NewJunctions(edges)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	maxHike := 0
-	for len(paths) > 0 {
-		path := paths[0]
-		copy(paths, paths[1:])
-		paths = paths[:len(paths)-1]
-
-		if path.Current == EndID {
-			maxHike = max(maxHike, path.Length)
-			continue
-		}
-
-		paths = append(paths, path.WalkAll(edges)...)
+	length, path, err := j.Longest(true)
+	if err != nil {
+		return solver.Answer{}, err
 	}
-	fmt.Printf("Max hike: %d\n", maxHike)
 
-	return nil
-}
-
-type Path struct {
-	Current int
-	Length  int
-	Visited Set[int]
-}
-
-func (p Path) Walk(e Edge) Path {
-	p.Current = e.End
-	p.Length += e.Length
-	p.Visited = p.Visited.Copy()
-	p.Visited[e.End] = struct{}{}
-
-	return p
-}
-
-func (p Path) WalkAll(edges []Edge) []Path {
-	next := make([]Path, 0, 3)
-
-	for _, edge := range edges {
-		// Filter other edges
-		if p.Current != edge.Start {
-			continue
-		}
-
-		// Filter visited end nodes
-		if _, ok := p.Visited[edge.End]; ok {
-			continue
+	if verbose {
+		names := make([]string, len(path))
+		for i, id := range path {
+			names[i] = nodeID(id)
 		}
-
-		next = append(next, p.Walk(edge))
+		fmt.Fprintln(os.Stderr, strings.Join(names, " -> "))
 	}
 
-	return next
-}
-
-type Set[T comparable] map[T]struct{}
-
-func (s Set[T]) Copy() Set[T] {
-	r := make(Set[T], len(s))
-	for k := range s {
-		r[k] = struct{}{}
-	}
-	return r
+	return solver.Int(length), nil
 }
 
 func generateGraph(m []string) []Edge {
-	nodes := make(map[Pos]int)
+	nodes := make(map[geom.Vec]int)
 	edges := make([]Edge, 0, 100)
 
-	startPos := Pos{findGap(m[0]), 0}
+	startPos := geom.Vec{X: findGap(m[0]), Y: 0}
 	nodes[startPos] = StartID
-	nodes[Pos{findGap(m[len(m)-1]), len(m) - 1}] = EndID
+	nodes[geom.Vec{X: findGap(m[len(m)-1]), Y: len(m) - 1}] = EndID
 
-	toVisit := []Pos{startPos}
+	toVisit := []geom.Vec{startPos}
 
 	for len(toVisit) > 0 {
 		startPos = toVisit[0]
@@ -161,7 +99,7 @@ func generateGraph(m []string) []Edge {
 	return edges
 }
 
-func followPath(m []string, current, last Pos) (int, Pos) {
+func followPath(m []string, current, last geom.Vec) (int, geom.Vec) {
 	length := 1
 
 	for {
@@ -179,7 +117,7 @@ func followPath(m []string, current, last Pos) (int, Pos) {
 	}
 }
 
-func findOptions(m []string, current, last Pos) Direction {
+func findOptions(m []string, current, last geom.Vec) Direction {
 	possibleDirs := Direction(0)
 	for a, dir := range dirs {
 		newPos := current.Add(a)
@@ -215,19 +153,19 @@ func (d Direction) Count() int {
 	return bits.OnesCount8(byte(d))
 }
 
-func (d Direction) RelativePos() Pos {
+func (d Direction) RelativePos() geom.Vec {
 	switch d {
 	case Up:
-		return Pos{Y: -1}
+		return geom.Vec{Y: -1}
 	case Down:
-		return Pos{Y: +1}
+		return geom.Vec{Y: +1}
 	case Left:
-		return Pos{X: -1}
+		return geom.Vec{X: -1}
 	case Right:
-		return Pos{X: +1}
+		return geom.Vec{X: +1}
 	}
 
-	return Pos{}
+	return geom.Vec{}
 }
 
 func (d Direction) Iterate() func(yield func(Direction) bool) {
@@ -250,24 +188,16 @@ const (
 	Right
 )
 
-var dirs = map[Pos]Direction{
+var dirs = map[geom.Vec]Direction{
 	{Y: -1}: Up,
 	{Y: +1}: Down,
 	{X: -1}: Left,
 	{X: +1}: Right,
 }
 
-type Pos struct {
-	X, Y int
-}
-
-func (p Pos) Add(o Pos) Pos {
-	return Pos{p.X + o.X, p.Y + o.Y}
-}
-
 type Node struct {
 	ID  int
-	Pos Pos
+	Pos geom.Vec
 }
 
 type Edge struct {
@@ -279,14 +209,14 @@ type Edge struct {
 //////////////////////////////////////////////////////
 
 func generateGraph2(m []string) []Edge {
-	nodes := make(map[Pos]int)
+	nodes := make(map[geom.Vec]int)
 	edges := make([]Edge, 0, 100)
 
-	startPos := Pos{findGap(m[0]), 0}
+	startPos := geom.Vec{X: findGap(m[0]), Y: 0}
 	nodes[startPos] = StartID
-	nodes[Pos{findGap(m[len(m)-1]), len(m) - 1}] = EndID
+	nodes[geom.Vec{X: findGap(m[len(m)-1]), Y: len(m) - 1}] = EndID
 
-	toVisit := []Pos{startPos}
+	toVisit := []geom.Vec{startPos}
 
 	for len(toVisit) > 0 {
 		startPos = toVisit[0]
@@ -320,7 +250,7 @@ func generateGraph2(m []string) []Edge {
 	return edges
 }
 
-func findOptions2(m []string, current, last Pos) Direction {
+func findOptions2(m []string, current, last geom.Vec) Direction {
 	possibleDirs := Direction(0)
 	for a, dir := range dirs {
 		newPos := current.Add(a)
@@ -340,7 +270,7 @@ func findOptions2(m []string, current, last Pos) Direction {
 	return possibleDirs
 }
 
-func followPath2(m []string, current, last Pos) (int, Pos) {
+func followPath2(m []string, current, last geom.Vec) (int, geom.Vec) {
 	length := 1
 
 	for {
@@ -358,44 +288,71 @@ func followPath2(m []string, current, last Pos) (int, Pos) {
 	}
 }
 
-func task2(args []string) error {
+// task2 finds the longest hike ignoring the slopes. It accepts -v like task1.
+func task2(args []string) (solver.Answer, error) {
+	m, err := parse.ReadLines(args[0])
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	return longestHike(generateGraph2(m), len(args) > 1 && args[1] == "-v")
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////
 
-	m, err := utils.ReadLines(args[0])
+func nodeID(id int) string {
+	switch id {
+	case StartID:
+		return "start"
+	case EndID:
+		return "end"
+	}
+	return strconv.Itoa(id)
+}
+
+// graph exports the junctions and trails with the longest hike highlighted. Pass 2 after the input
+// file to ignore the slopes like in part 2.
+func graph(args []string) (dot.Grapher, error) {
+	m, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	edges := generateGraph2(m)
-	fmt.Println(edges)
+	directed := len(args) < 2 || args[1] != "2"
 
-	paths := []Path{
-		{
-			Current: StartID,
-			Length:  0,
-			Visited: make(Set[int]),
-		},
+	var edges []Edge
+	if directed {
+		edges = generateGraph(m)
+	} else {
+		edges = generateGraph2(m)
 	}
 
-	maxLen := make(map[int]int)
-	for len(paths) > 0 {
-		path := paths[0]
-		copy(paths, paths[1:])
-		paths = paths[:len(paths)-1]
-
-		newPaths := path.WalkAll(edges)
-		for _, newPath := range newPaths {
-			maxLen[newPath.Current] = max(maxLen[newPath.Current], newPath.Length)
-			if newPath.Current == EndID {
-				fmt.Printf("Found end: %d\n", maxLen[EndID])
-			}
+	g := dot.New("trails", directed)
+	for _, edge := range edges {
+		g.Node(nodeID(edge.Start), "")
+		g.Node(nodeID(edge.End), "")
+
+		// the undirected graph contains every trail in both directions
+		if directed || edge.Start < edge.End || edge.End == EndID {
+			g.Edge(nodeID(edge.Start), nodeID(edge.End), strconv.Itoa(edge.Length))
 		}
+	}
+
+	j, err := NewJunctions(edges)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ids, err := j.Longest(true)
+	if err != nil {
+		return nil, err
+	}
 
-		paths = append(paths, newPaths...)
-		slices.SortFunc(paths, func(a, b Path) int {
-			return b.Length - a.Length
-		})
+	path := make([]string, len(ids))
+	for i, id := range ids {
+		path[i] = nodeID(id)
 	}
-	fmt.Printf("Max hike: %d\n", maxLen[EndID])
+	g.HighlightPath(path...)
 
-	return nil
+	return g, nil
 }
@@ -1,114 +1,59 @@
-package main
+package day24
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"math/big"
 	"strconv"
 	"strings"
 
-	"github.com/noxer/aoc/2023/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
-}
-
-type Vec3 struct {
-	X, Y, Z float64
+func init() {
+	solver.Register(2023, 24, task1, task2)
 }
 
-func parseVec3(str string) Vec3 {
+func parseVec3(str string) geom.Vec3 {
 	parts := strings.Split(str, ", ")
-	v := Vec3{}
-	v.X, _ = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
-	v.Y, _ = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-	v.Z, _ = strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+	v := geom.Vec3{}
+	v.X, _ = strconv.Atoi(strings.TrimSpace(parts[0]))
+	v.Y, _ = strconv.Atoi(strings.TrimSpace(parts[1]))
+	v.Z, _ = strconv.Atoi(strings.TrimSpace(parts[2]))
 	return v
 }
 
+// Hailstone moves along its trajectory, the parameter of the line is the time.
 type Hailstone struct {
-	Position Vec3
-	Velocity Vec3
-}
-
-func (h Hailstone) CheckX(x float64) bool {
-	if h.Velocity.X > 0 {
-		return x >= h.Position.X
-	}
-
-	return x <= h.Position.X
-}
-
-func (h Hailstone) ToLine2() Trajectory {
-	t := Trajectory{}
-	t.Incline = h.Velocity.Y / h.Velocity.X
-	t.Offset = t.Incline*-h.Position.X + h.Position.Y
-	return t
-}
-
-type Trajectory struct {
-	Offset, Incline float64
-}
-
-func (t Trajectory) Eval(x float64) float64 {
-	return t.Incline*x + t.Offset
-}
-
-func (t Trajectory) Intersects(o Trajectory) (Vec3, bool) {
-	// t.Incline * x + t.Offset = o.Incline * x + o.Offset | - o.Offset
-	// t.Incline * x = o.Incline * x + o.Offset - t.Offset | - o.Incline * x
-	// (t.Incline-o.Incline) * x = o.Offset - t.Offset     |
-	inc := t.Incline - o.Incline
-	if inc == 0 {
-		return Vec3{}, false
-	}
-
-	x := (o.Offset - t.Offset) / inc
-
-	return Vec3{X: x, Y: t.Eval(x)}, true
+	geom.Line3
 }
 
 func parseHailstone(line string) Hailstone {
 	pos, vel, _ := strings.Cut(line, " @ ")
 	h := Hailstone{
-		Position: parseVec3(pos),
-		Velocity: parseVec3(vel),
+		Line3: geom.Line3{
+			P: parseVec3(pos),
+			D: parseVec3(vel),
+		},
 	}
 	return h
 }
 
-func hailstonesCollide(a, b Hailstone) (Vec3, bool) {
-	pos, ok := a.ToLine2().Intersects(b.ToLine2())
-	if !ok {
-		return Vec3{}, false
+// hailstonesCollide returns the point where the paths of the hailstones cross in the XY plane.
+// Crossings in the past don't count.
+func hailstonesCollide(a, b Hailstone) (geom.RatVec3, bool) {
+	t, s, rel := a.Intersect2(b.Line3)
+	if rel != geom.Crossing || t.Sign() < 0 || s.Sign() < 0 {
+		return geom.RatVec3{}, false
 	}
 
-	valid := a.CheckX(pos.X) && b.CheckX(pos.X)
-	return pos, valid
+	return a.At(t), true
 }
 
 type BoundingBox struct {
-	A, B Vec3
+	A, B geom.Vec3
 }
 
 func (bb BoundingBox) HailstonesCollide(a, b Hailstone) bool {
@@ -117,24 +62,37 @@ func (bb BoundingBox) HailstonesCollide(a, b Hailstone) bool {
 		return false
 	}
 
-	return pos.X >= bb.A.X && pos.X <= bb.B.X &&
-		pos.Y >= bb.A.Y && pos.Y <= bb.B.Y
+	minimum, maximum := bb.A.Rat(), bb.B.Rat()
+
+	return pos.X.Cmp(minimum.X) >= 0 && pos.X.Cmp(maximum.X) <= 0 &&
+		pos.Y.Cmp(minimum.Y) >= 0 && pos.Y.Cmp(maximum.Y) <= 0
 }
 
-func task1(args []string) error {
-	hailstones, err := utils.ReadLinesTransform(args[0], parseHailstone)
+// task1 counts the crossings in the test area. The bounds of the area can be passed after the
+// input file, e.g. "7 27" for the example.
+func task1(args []string) (solver.Answer, error) {
+	hailstones, err := parse.ReadLinesTransform(args[0], parseHailstone)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	// box := BoundingBox{
-	// 	A: Vec3{X: 7, Y: 7},
-	// 	B: Vec3{X: 27, Y: 27},
-	// }
-
 	box := BoundingBox{
-		A: Vec3{X: 200000000000000, Y: 200000000000000},
-		B: Vec3{X: 400000000000000, Y: 400000000000000},
+		A: geom.Vec3{X: 200000000000000, Y: 200000000000000},
+		B: geom.Vec3{X: 400000000000000, Y: 400000000000000},
+	}
+
+	if len(args) > 2 {
+		low, err := strconv.Atoi(args[1])
+		if err != nil {
+			return solver.Answer{}, fmt.Errorf("invalid lower bound %q: %w", args[1], err)
+		}
+		high, err := strconv.Atoi(args[2])
+		if err != nil {
+			return solver.Answer{}, fmt.Errorf("invalid upper bound %q: %w", args[2], err)
+		}
+
+		box.A = geom.Vec3{X: low, Y: low}
+		box.B = geom.Vec3{X: high, Y: high}
 	}
 
 	counter := 0
@@ -146,28 +104,37 @@ func task1(args []string) error {
 		}
 	}
 
-	fmt.Printf("Counter: %d\n", counter)
-
-	return nil
+	return solver.Int(counter), nil
 }
 
-func task2(args []string) error {
-	return nil
-}
+///////////////////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////////
 
-// one vector to intersect them all
-
-// |
-// |     \
-// -------\------x
-// |\      x
-// | \      \
-// |  \      \
-// |   \      \
-// +----\------------------
-// |     \
-// |
-// |
-// |
-// |
-// |
+// task2 finds the one trajectory which hits all hailstones. At the time of each hit, the rock and
+// the hailstone are at the same position, so the rock is the line meeting every hailstone at the
+// same parameter.
+func task2(args []string) (solver.Answer, error) {
+	hailstones, err := parse.ReadLinesTransform(args[0], parseHailstone)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	lines := make([]geom.Line3, len(hailstones))
+	for i, h := range hailstones {
+		lines[i] = h.Line3
+	}
+
+	pos, _, ok := geom.ThroughAll(lines)
+	if !ok {
+		return solver.Answer{}, errors.New("no trajectory hits all hailstones")
+	}
+
+	sum := new(big.Rat).Add(pos.X, pos.Y)
+	sum.Add(sum, pos.Z)
+	if !sum.IsInt() {
+		return solver.Answer{}, fmt.Errorf("the rock starts at %s, which isn't a whole position", pos)
+	}
+
+	return solver.BigInt(sum.Num()), nil
+}
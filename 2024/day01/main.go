@@ -1,47 +1,26 @@
-package main
+package day01
 
 import (
-	"fmt"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 1, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task1(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task1(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	var left, right []int
@@ -64,19 +43,17 @@ func task1(args []string) error {
 		sum += diff
 	}
 
-	fmt.Printf("Difference: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task2(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	var left []int
@@ -97,7 +74,5 @@ func task2(args []string) error {
 		sum += score
 	}
 
-	fmt.Printf("Score: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
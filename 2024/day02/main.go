@@ -1,37 +1,16 @@
-package main
+package day02
 
 import (
-	"fmt"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 2, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -66,8 +45,8 @@ func checkDesc(r Report) bool {
 	return true
 }
 
-func task1(args []string) error {
-	reports, err := utils.ReadLinesTransform(args[0], func(line string) Report {
+func task1(args []string) (solver.Answer, error) {
+	reports, err := parse.ReadLinesTransform(args[0], func(line string) Report {
 		parts := strings.Fields(line)
 		report := make(Report, len(parts))
 		for i, p := range parts {
@@ -76,7 +55,7 @@ func task1(args []string) error {
 		return report
 	})
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	count := 0
@@ -86,9 +65,7 @@ func task1(args []string) error {
 		}
 	}
 
-	fmt.Printf("Safe reports: %d\n", count)
-
-	return nil
+	return solver.Int(count), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -138,8 +115,8 @@ func deleteCopy(r Report, idx int) Report {
 	return out
 }
 
-func task2(args []string) error {
-	reports, err := utils.ReadLinesTransform(args[0], func(line string) Report {
+func task2(args []string) (solver.Answer, error) {
+	reports, err := parse.ReadLinesTransform(args[0], func(line string) Report {
 		parts := strings.Fields(line)
 		report := make(Report, len(parts))
 		for i, p := range parts {
@@ -148,7 +125,7 @@ func task2(args []string) error {
 		return report
 	})
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	count := 0
@@ -158,7 +135,5 @@ func task2(args []string) error {
 		}
 	}
 
-	fmt.Printf("Safe reports: %d\n", count)
-
-	return nil
+	return solver.Int(count), nil
 }
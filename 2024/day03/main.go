@@ -1,34 +1,15 @@
-package main
+package day03
 
 import (
-	"fmt"
 	"os"
 	"regexp"
 	"strconv"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 3, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -37,10 +18,10 @@ func main() {
 
 var mul = regexp.MustCompile(`mul\((\d+),(\d+)\)`)
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	memory, err := loadString(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	matches := mul.FindAllStringSubmatch(memory, -1)
@@ -51,9 +32,7 @@ func task1(args []string) error {
 		sum += a * b
 	}
 
-	fmt.Printf("Sum: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 func loadString(name string) (string, error) {
@@ -67,10 +46,10 @@ func loadString(name string) (string, error) {
 
 var mulOrDo = regexp.MustCompile(`mul\((\d+),(\d+)\)|do\(\)|don't\(\)`)
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	memory, err := loadString(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	matches := mulOrDo.FindAllStringSubmatch(memory, -1)
@@ -93,7 +72,5 @@ func task2(args []string) error {
 		}
 	}
 
-	fmt.Printf("Sum: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
@@ -1,45 +1,24 @@
-package main
+package day04
 
 import (
-	"fmt"
-	"os"
 	"strings"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 4, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task1(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task1(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	total := 0
@@ -48,9 +27,7 @@ func task1(args []string) error {
 	total += countDiagonalDown(lines, "XMAS", "SAMX")
 	total += countDiagonalUp(lines, "XMAS", "SAMX")
 
-	fmt.Printf("Total: %d\n", total)
-
-	return nil
+	return solver.Int(total), nil
 }
 
 func countHorizontal(haystack []string, needles ...string) int {
@@ -169,10 +146,10 @@ func matchPattern(lines []string, p Pos) bool {
 	return first && second
 }
 
-func task2(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task2(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	count := 0
@@ -184,6 +161,5 @@ func task2(args []string) error {
 		}
 	}
 
-	fmt.Printf("Count: %d\n", count)
-	return nil
+	return solver.Int(count), nil
 }
@@ -1,37 +1,17 @@
-package main
+package day05
 
 import (
 	"bufio"
-	"fmt"
 	"os"
 	"slices"
 	"strconv"
 	"strings"
-	"time"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 5, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -117,14 +97,12 @@ func applyRules(rules []Rule, batch Batch) bool {
 	return true
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	rules, batches, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	sum := 0
 	for _, batch := range batches {
 		if applyRules(rules, batch) {
@@ -132,10 +110,7 @@ func task1(args []string) error {
 		}
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Sum: %d, %s\n", sum, elapsed)
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -157,10 +132,10 @@ func enforceRules(rules []Rule, batch Batch) {
 	})
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	rules, batches, err := parseFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := 0
@@ -171,6 +146,5 @@ func task2(args []string) error {
 		}
 	}
 
-	fmt.Printf("Sum: %d\n", sum)
-	return nil
+	return solver.Int(sum), nil
 }
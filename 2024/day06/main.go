@@ -1,34 +1,16 @@
-package main
+package day06
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"time"
-)
-
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"errors"
+	"iter"
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/grid"
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 6, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -36,48 +18,38 @@ func main() {
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 var (
-	Up    = Vec{Y: -1}
-	Down  = Vec{Y: +1}
-	Left  = Vec{X: -1}
-	Right = Vec{X: +1}
+	Up    = geom.Vec{Y: -1}
+	Down  = geom.Vec{Y: +1}
+	Left  = geom.Vec{X: -1}
+	Right = geom.Vec{X: +1}
 )
 
-var rightTurn = map[Vec]Vec{
+var rightTurn = map[geom.Vec]geom.Vec{
 	Up:    Right,
 	Right: Down,
 	Down:  Left,
 	Left:  Up,
 }
 
-type Vec struct {
-	X, Y int
-}
-
-func (v Vec) Add(o Vec) Vec {
-	return Vec{v.X + o.X, v.Y + o.Y}
-}
-
 type Map struct {
-	size Vec
-	data map[Vec]byte
+	data grid.Grid[byte]
 }
 
-func (m Map) Contains(v Vec) bool {
-	return v.X >= 0 && v.X < m.size.X &&
-		v.Y >= 0 && v.Y < m.size.Y
+func (m Map) Contains(v geom.Vec) bool {
+	return m.data.InBounds(v)
 }
 
-func (m Map) Obstacle(v Vec) bool {
-	return m.data[v] == '#'
+func (m Map) Obstacle(v geom.Vec) bool {
+	return m.data.At(v) == '#'
 }
 
-func (m Map) Mark(v Vec) {
-	m.data[v] = 'X'
+func (m Map) Mark(v geom.Vec) {
+	m.data.Set(v, 'X')
 }
 
 func (m Map) CountMarks() int {
 	count := 0
-	for _, b := range m.data {
+	for _, b := range m.data.All() {
 		if b == 'X' {
 			count++
 		}
@@ -85,43 +57,29 @@ func (m Map) CountMarks() int {
 	return count
 }
 
-func parseMap(name string) (Map, Vec, error) {
-	f, err := os.Open(name)
+func parseMap(name string) (Map, geom.Vec, error) {
+	data, err := grid.Read(name)
 	if err != nil {
-		return Map{}, Vec{}, err
+		return Map{}, geom.Vec{}, err
 	}
-	defer f.Close()
 
-	s := bufio.NewScanner(f)
-
-	m := Map{
-		data: make(map[Vec]byte),
+	start, ok := grid.Find(data, '^')
+	if !ok {
+		return Map{}, geom.Vec{}, errors.New("guard not found")
 	}
-	start := Vec{}
-	y := 0
-	for s.Scan() {
-		for x, b := range s.Bytes() {
-			switch b {
-			case '#':
-				m.data[Vec{x, y}] = '#'
-			case '^':
-				start = Vec{x, y}
-				m.data[start] = 'X'
-			}
-		}
 
-		y++
-		m.size.X = len(s.Bytes())
-		m.size.Y = y
+	m := Map{
+		data: data,
 	}
+	m.Mark(start)
 
 	return m, start, nil
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	m, s, err := parseMap(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	pos := s
@@ -141,23 +99,21 @@ func task1(args []string) error {
 		pos = newPos
 	}
 
-	fmt.Printf("Marked: %d\n", m.CountMarks())
-
-	return nil
+	return solver.Int(m.CountMarks()), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-var dirMarker = map[Vec]byte{
+var dirMarker = map[geom.Vec]byte{
 	Up:    '^',
 	Down:  'v',
 	Left:  '<',
 	Right: '>',
 }
 
-func (m Map) Walk(start Vec) {
+func (m Map) Walk(start geom.Vec) {
 	pos := start
 	dir := Up
 	for {
@@ -176,15 +132,15 @@ func (m Map) Walk(start Vec) {
 	}
 }
 
-func (m Map) MarkAs(v Vec, b byte) {
-	m.data[v] = b
+func (m Map) MarkAs(v geom.Vec, b byte) {
+	m.data.Set(v, b)
 }
 
-func (m Map) Check(v Vec, b byte) bool {
-	return m.data[v] == b
+func (m Map) Check(v geom.Vec, b byte) bool {
+	return m.data.At(v) == b
 }
 
-func (m Map) WalkWithMovementMap(start Vec, mm map[PosDir]struct{}) bool {
+func (m Map) WalkWithMovementMap(start geom.Vec, mm map[PosDir]struct{}) bool {
 	pos := start
 	dir := Up
 	for {
@@ -207,9 +163,9 @@ func (m Map) WalkWithMovementMap(start Vec, mm map[PosDir]struct{}) bool {
 	}
 }
 
-func (m Map) IterateEqual(e byte) func(func(Vec) bool) {
-	return func(yield func(Vec) bool) {
-		for v, b := range m.data {
+func (m Map) IterateEqual(e byte) iter.Seq[geom.Vec] {
+	return func(yield func(geom.Vec) bool) {
+		for v, b := range m.data.All() {
 			if b == e {
 				if !yield(v) {
 					return
@@ -220,38 +176,29 @@ func (m Map) IterateEqual(e byte) func(func(Vec) bool) {
 }
 
 func (m Map) Copy() Map {
-	c := Map{
-		size: m.size,
-		data: make(map[Vec]byte, len(m.data)),
+	return Map{
+		data: m.data.Clone(),
 	}
-
-	for v, b := range m.data {
-		c.data[v] = b
-	}
-
-	return c
 }
 
 func (m Map) Reset() {
-	for v, b := range m.data {
+	for v, b := range m.data.All() {
 		if b != '#' {
-			delete(m.data, v)
+			m.data.Set(v, '.')
 		}
 	}
 }
 
 type PosDir struct {
-	Pos, Dir Vec
+	Pos, Dir geom.Vec
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	m, s, err := parseMap(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	m.Walk(s)
 	movementMap := make(map[PosDir]struct{})
 	count := 0
@@ -268,9 +215,5 @@ func task2(args []string) error {
 		clear(movementMap)
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Count: %d (%s)\n", count, elapsed)
-
-	return nil
+	return solver.Int(count), nil
 }
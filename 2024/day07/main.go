@@ -1,39 +1,17 @@
-package main
+package day07
 
 import (
-	"fmt"
-	"os"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 7, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -79,14 +57,12 @@ func tryOps(current int, values []int, check func(int) bool) bool {
 	return tryOps(prod, values[1:], check)
 }
 
-func task1(args []string) error {
-	equations, err := utils.ReadLinesTransform(args[0], parseEquation)
+func task1(args []string) (solver.Answer, error) {
+	equations, err := parse.ReadLinesTransform(args[0], parseEquation)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	sum := 0
 	for _, equation := range equations {
 		if equation.HasSolution() {
@@ -94,11 +70,7 @@ func task1(args []string) error {
 		}
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Result: %d (%s)\n", sum, elapsed)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -137,14 +109,12 @@ func concat(a, b int) int {
 	return a*shift + b
 }
 
-func task2(args []string) error {
-	equations, err := utils.ReadLinesTransform(args[0], parseEquation)
+func task2(args []string) (solver.Answer, error) {
+	equations, err := parse.ReadLinesTransform(args[0], parseEquation)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	in := make(chan Equation, 8)
 	out := make(chan int, 8)
 	wg := sync.WaitGroup{}
@@ -181,9 +151,5 @@ func task2(args []string) error {
 		sum += res
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Result: %d (%s)\n", sum, elapsed)
-
-	return nil
+	return solver.Int(sum), nil
 }
@@ -1,34 +1,14 @@
-package main
+package day08
 
 import (
 	"bufio"
-	"fmt"
 	"os"
-	"time"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 8, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -117,16 +97,15 @@ func parseMap(name string) (Map, error) {
 	return m, nil
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	m, err := parseMap(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	count := m.CountAntinodes()
 
-	fmt.Printf("Antinodes: %d\n", count)
-	return nil
+	return solver.Int(count), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -154,18 +133,13 @@ func (m Map) CountAntinodes2() int {
 	return len(antinodes)
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	m, err := parseMap(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	count := m.CountAntinodes2()
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Antinodes: %d (%s)\n", count, elapsed)
-	return nil
+	return solver.Int(count), nil
 }
@@ -1,37 +1,15 @@
-package main
+package day09
 
 import (
 	"bufio"
-	"fmt"
 	"os"
 	"slices"
-	"time"
-)
-
-func main() {
-	var err error
-
-	//	task1("example.txt")
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:]...)
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 9, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -137,6 +115,11 @@ func parseDiskMap(name string) (*FileSystem, error) {
 	id := 0
 	fs := &FileSystem{}
 	for b, err := r.ReadByte(); err == nil; b, err = r.ReadByte() {
+		// skip the trailing newline
+		if b < '0' || b > '9' {
+			continue
+		}
+
 		f := File{
 			Size: int(b - '0'),
 		}
@@ -155,22 +138,16 @@ func parseDiskMap(name string) (*FileSystem, error) {
 	return fs, nil
 }
 
-func task1(args ...string) error {
+func task1(args []string) (solver.Answer, error) {
 	fs, err := parseDiskMap(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	fs.Defrag()
 	checksum := fs.Checksum()
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Checksum: %d (%s)\n", checksum, elapsed)
-
-	return nil
+	return solver.Int(checksum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -211,20 +188,14 @@ func (fs *FileSystem) DefragSpace() {
 	}
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	fs, err := parseDiskMap(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	fs.DefragSpace()
 	checksum := fs.Checksum()
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Checksum: %d (%s)\n", checksum, elapsed)
-
-	return nil
+	return solver.Int(checksum), nil
 }
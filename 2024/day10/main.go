@@ -1,42 +1,20 @@
-package main
+package day10
 
 import (
-	"fmt"
-	"os"
-	"time"
-
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 10, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-var directions = []utils.Vec{
+var directions = []geom.Vec{
 	{X: +1}, // right
 	{X: -1}, // left
 	{Y: +1}, // down
@@ -47,15 +25,15 @@ type Map struct {
 	data []string
 }
 
-func (m Map) IterateTrailheads() func(func(utils.Vec) bool) {
-	return func(yield func(utils.Vec) bool) {
+func (m Map) IterateTrailheads() func(func(geom.Vec) bool) {
+	return func(yield func(geom.Vec) bool) {
 		for y, row := range m.data {
 			for x, r := range []byte(row) {
 				if r != '0' {
 					continue
 				}
 
-				if !yield(utils.Vec{X: x, Y: y}) {
+				if !yield(geom.Vec{X: x, Y: y}) {
 					return
 				}
 			}
@@ -63,7 +41,7 @@ func (m Map) IterateTrailheads() func(func(utils.Vec) bool) {
 	}
 }
 
-func (m Map) Get(pos utils.Vec) int {
+func (m Map) Get(pos geom.Vec) int {
 	if pos.Y < 0 || pos.Y >= len(m.data) {
 		return -1
 	}
@@ -77,11 +55,11 @@ func (m Map) Get(pos utils.Vec) int {
 	return int(b)
 }
 
-func (m Map) FindScore(start utils.Vec) int {
-	positions := make([]utils.Vec, 1, 256)
+func (m Map) FindScore(start geom.Vec) int {
+	positions := make([]geom.Vec, 1, 256)
 	positions[0] = start
-	peeks := make(map[utils.Vec]struct{})
-	into := make([]utils.Vec, 4)
+	peeks := make(map[geom.Vec]struct{})
+	into := make([]geom.Vec, 4)
 
 	for len(positions) > 0 {
 		pos := positions[0]
@@ -100,7 +78,7 @@ func (m Map) FindScore(start utils.Vec) int {
 	return len(peeks)
 }
 
-func (m Map) findNextSteps(from utils.Vec, into []utils.Vec) []utils.Vec {
+func (m Map) findNextSteps(from geom.Vec, into []geom.Vec) []geom.Vec {
 	fromVal := m.Get(from)
 
 	for _, dir := range directions {
@@ -116,10 +94,10 @@ func (m Map) findNextSteps(from utils.Vec, into []utils.Vec) []utils.Vec {
 	return into
 }
 
-func task1(args []string) error {
-	data, err := utils.ReadLines(args[0])
+func task1(args []string) (solver.Answer, error) {
+	data, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	m := Map{data: data}
@@ -129,19 +107,17 @@ func task1(args []string) error {
 		sum += m.FindScore(head)
 	}
 
-	fmt.Printf("Sum: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func (m Map) FindRating(start utils.Vec) int {
-	positions := []utils.Vec{start}
+func (m Map) FindRating(start geom.Vec) int {
+	positions := []geom.Vec{start}
 	trails := 0
-	into := make([]utils.Vec, 4)
+	into := make([]geom.Vec, 4)
 
 	for len(positions) > 0 {
 		pos := positions[0]
@@ -160,24 +136,18 @@ func (m Map) FindRating(start utils.Vec) int {
 	return trails
 }
 
-func task2(args []string) error {
-	data, err := utils.ReadLines(args[0])
+func task2(args []string) (solver.Answer, error) {
+	data, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	m := Map{data}
 
-	start := time.Now()
-
 	sum := 0
 	for head := range m.IterateTrailheads() {
 		sum += m.FindRating(head)
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Sum: %d (%s)\n", sum, elapsed)
-
-	return nil
+	return solver.Int(sum), nil
 }
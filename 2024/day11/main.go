@@ -1,37 +1,16 @@
-package main
+package day11
 
 import (
-	"fmt"
 	"os"
 	"slices"
 	"strconv"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 11, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -59,21 +38,19 @@ func applyRules(stones []int) []int {
 	return stones
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	p, err := os.ReadFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	stones := utils.ParseInts(string(p), " ")
+	stones := parse.ParseInts(string(p), " ")
 
 	for range 25 {
 		stones = applyRules(stones)
 	}
 
-	fmt.Printf("Stones count: %d\n", len(stones))
-
-	return nil
+	return solver.Int(len(stones)), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -110,15 +87,13 @@ func countStones(cache map[Key]int, stone, steps int) (count int) {
 	}
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	p, err := os.ReadFile(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	stones := utils.ParseInts(string(p), " ")
-
-	start := time.Now()
+	stones := parse.ParseInts(string(p), " ")
 
 	sum := 0
 	cache := make(map[Key]int)
@@ -126,9 +101,5 @@ func task2(args []string) error {
 		sum += countStones(cache, stone, 75)
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Stones count: %d (%s)\n", sum, elapsed)
-
-	return nil
+	return solver.Int(sum), nil
 }
@@ -1,42 +1,20 @@
-package main
+package day12
 
 import (
-	"fmt"
-	"os"
-	"time"
-
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 12, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-var directions = []utils.Vec{
+var directions = []geom.Vec{
 	{X: -1}, // left
 	{X: +1}, // right
 	{Y: -1}, // up
@@ -53,7 +31,7 @@ type Map [][]Node
 func (m Map) ConnectNodes() {
 	for y, row := range m {
 		for x, node := range row {
-			pos := utils.Vec{X: x, Y: y}
+			pos := geom.Vec{X: x, Y: y}
 
 			for _, dir := range directions {
 				conn := m.Get(pos.Add(dir))
@@ -107,7 +85,7 @@ func (m Map) calculateRegion(node *Node, seen map[*Node]struct{}) (int, int) {
 	return area, perimeter
 }
 
-func (m Map) Get(pos utils.Vec) *Node {
+func (m Map) Get(pos geom.Vec) *Node {
 	if pos.X < 0 || pos.Y < 0 {
 		return nil
 	}
@@ -132,23 +110,17 @@ func parseNodes(line string) []Node {
 	return nodes
 }
 
-func task1(args []string) error {
-	r, err := utils.ReadLinesTransform(args[0], parseNodes)
+func task1(args []string) (solver.Answer, error) {
+	r, err := parse.ReadLinesTransform(args[0], parseNodes)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	m := Map(r)
 	m.ConnectNodes()
 	price := m.CalculatePrice()
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Price: %d (%s)\n", price, elapsed)
-
-	return nil
+	return solver.Int(price), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -174,7 +146,7 @@ type Map2 [][]Node2
 func (m Map2) ConnectNodes() {
 	for y, row := range m {
 		for x, node := range row {
-			pos := utils.Vec{X: x, Y: y}
+			pos := geom.Vec{X: x, Y: y}
 
 			for i, dir := range directions {
 				conn := m.Get(pos.Add(dir))
@@ -249,7 +221,7 @@ func (m Map2) hasPerimeter(node *Node2, lookDir int) bool {
 	return node != nil && node.Links[lookDir] == nil
 }
 
-func (m Map2) Get(pos utils.Vec) *Node2 {
+func (m Map2) Get(pos geom.Vec) *Node2 {
 	if pos.X < 0 || pos.Y < 0 {
 		return nil
 	}
@@ -274,21 +246,15 @@ func parseNodes2(line string) []Node2 {
 	return nodes
 }
 
-func task2(args []string) error {
-	r, err := utils.ReadLinesTransform(args[0], parseNodes2)
+func task2(args []string) (solver.Answer, error) {
+	r, err := parse.ReadLinesTransform(args[0], parseNodes2)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	m := Map2(r)
 	m.ConnectNodes()
 	price := m.CalculatePrice()
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Price: %d (%s)\n", price, elapsed)
-
-	return nil
+	return solver.Int(price), nil
 }
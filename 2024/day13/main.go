@@ -1,39 +1,18 @@
-package main
+package day13
 
 import (
 	"bufio"
-	"fmt"
-	"math"
 	"os"
 	"regexp"
 	"strconv"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/mathx"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 13, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -41,9 +20,9 @@ func main() {
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 type Machine struct {
-	ButtonA utils.Vec
-	ButtonB utils.Vec
-	Prize   utils.Vec
+	ButtonA geom.Vec
+	ButtonB geom.Vec
+	Prize   geom.Vec
 }
 
 func (m Machine) Cost() int {
@@ -66,7 +45,7 @@ func (m Machine) Cost() int {
 	return cost
 }
 
-func lowerEq(a, b utils.Vec) bool {
+func lowerEq(a, b geom.Vec) bool {
 	return a.X <= b.X && a.Y <= b.Y
 }
 
@@ -93,7 +72,7 @@ func parseMachines(name string) ([]Machine, error) {
 			continue
 		}
 
-		v := utils.Vec{}
+		v := geom.Vec{}
 		v.X, _ = strconv.Atoi(matches[2])
 		v.Y, _ = strconv.Atoi(matches[3])
 
@@ -115,10 +94,10 @@ func parseMachines(name string) ([]Machine, error) {
 	return machines, nil
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	machines, err := parseMachines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := 0
@@ -127,106 +106,33 @@ func task1(args []string) error {
 		sum += cost
 	}
 
-	fmt.Printf("Cost: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-type Line struct {
-	Slope  float64
-	Offset float64
-}
-
-type Vec2 struct {
-	X, Y float64
-}
-
-func (v Vec2) Sub(o Vec2) Vec2 {
-	return Vec2{
-		X: v.X - o.X,
-		Y: v.Y - o.Y,
-	}
-}
-
-func (l Line) MoveThrough(pos utils.Vec) Line {
-	y := l.Slope * float64(pos.X)
-	l.Offset = float64(pos.Y) - y
-	return l
-}
-
-func (l Line) Eval(x float64) float64 {
-	return l.Slope*x + l.Offset
-}
-
-func (l Line) Intersection(o Line) (Vec2, bool) {
-	slope := l.Slope - o.Slope
-	if slope == 0 {
-		return Vec2{}, false
-	}
-
-	x := (o.Offset - l.Offset) / slope
-
-	return Vec2{X: x, Y: l.Eval(x)}, true
-}
-
-func lineFromVec(v utils.Vec) Line {
-	return Line{
-		Slope: float64(v.Y) / float64(v.X),
-	}
-}
-
+// FastCost solves the system of linear equations for the number of button presses exactly.
 func (m Machine) FastCost() int {
-	lineA := lineFromVec(m.ButtonA)
-	lineB := lineFromVec(m.ButtonB)
-
-	lineB = lineB.MoveThrough(m.Prize)
-
-	intersection, ok := lineA.Intersection(lineB)
-	if !ok {
-		return 0
-	}
-
-	a := checkMultiple(m.ButtonA, intersection)
-
-	prizeVec2 := Vec2{X: float64(m.Prize.X), Y: float64(m.Prize.Y)}
-	prizeOffset := prizeVec2.Sub(intersection)
-
-	b := checkMultiple(m.ButtonB, prizeOffset)
-
-	maybePrize := m.ButtonA.Mul(int(a)).Add(m.ButtonB.Mul(int(b)))
-	if maybePrize != m.Prize {
-		// fmt.Printf("\nFound discrepancy: %v vs %v\n", maybePrize, m.Prize)
+	a, b, ok := mathx.Cramer(
+		m.ButtonA.X, m.ButtonB.X,
+		m.ButtonA.Y, m.ButtonB.Y,
+		m.Prize.X, m.Prize.Y,
+	)
+	if !ok || a < 0 || b < 0 {
 		return 0
 	}
 
 	return a*3 + b
 }
 
-func checkMultiple(v utils.Vec, intersection Vec2) int {
-	divX := intersection.X / float64(v.X)
-	divY := intersection.Y / float64(v.Y)
-
-	// fmt.Printf("checkMultiple %v %v: %f and %f\n", v, intersection, divX, divY)
-
-	if int(math.Round(divX)) != int(math.Round(divY)) {
-		return 0
-	}
-
-	return int(math.Round(divX))
-}
-
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	machines, err := parseMachines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	sum := 0
 	for _, machine := range machines {
 		// fmt.Printf("Solving machine %d...", i)
@@ -241,9 +147,5 @@ func task2(args []string) error {
 		// fmt.Println("ok")
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Cost: %d (%s)\n", sum, elapsed)
-
-	return nil
+	return solver.Int(sum), nil
 }
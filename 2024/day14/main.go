@@ -1,37 +1,20 @@
-package main
+package day14
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/mathx"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 14, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -39,8 +22,8 @@ func main() {
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 type Robot struct {
-	Pos utils.Vec
-	Vel utils.Vec
+	Pos geom.Vec
+	Vel geom.Vec
 }
 
 func parseRobot(line string) Robot {
@@ -52,8 +35,8 @@ func parseRobot(line string) Robot {
 	}
 }
 
-func parseVec(part string) utils.Vec {
-	var result utils.Vec
+func parseVec(part string) geom.Vec {
+	var result geom.Vec
 
 	part = part[2:]
 	rawX, rawY, _ := strings.Cut(part, ",")
@@ -64,7 +47,7 @@ func parseVec(part string) utils.Vec {
 	return result
 }
 
-func mapToRoom(width, height int, pos utils.Vec) utils.Vec {
+func mapToRoom(width, height int, pos geom.Vec) geom.Vec {
 	x := pos.X % width
 	y := pos.Y % height
 
@@ -75,13 +58,13 @@ func mapToRoom(width, height int, pos utils.Vec) utils.Vec {
 		y += height
 	}
 
-	return utils.Vec{
+	return geom.Vec{
 		X: x,
 		Y: y,
 	}
 }
 
-func countQuadrant(pos []utils.Vec, width, height int) (a, b, c, d int) {
+func countQuadrant(pos []geom.Vec, width, height int) (a, b, c, d int) {
 	centerX := width / 2
 	centerY := height / 2
 
@@ -101,19 +84,35 @@ func countQuadrant(pos []utils.Vec, width, height int) (a, b, c, d int) {
 	return a, b, c, d
 }
 
-func task1(args []string) error {
-	robots, err := utils.ReadLinesTransform(args[0], parseRobot)
+// roomSize returns the size of the room, it defaults to 101x103 but can be passed after the input
+// file.
+func roomSize(args []string) (width, height int, err error) {
+	width, err = parse.IntArg(args, 1, 101)
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
 
-	width := 101
-	height := 103
-	// width := 11
-	// height := 7
+	height, err = parse.IntArg(args, 2, 103)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return width, height, nil
+}
+
+func task1(args []string) (solver.Answer, error) {
+	robots, err := parse.ReadLinesTransform(args[0], parseRobot)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	width, height, err := roomSize(args)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 	seconds := 100
 
-	pos := make([]utils.Vec, len(robots))
+	pos := make([]geom.Vec, len(robots))
 	for i, robot := range robots {
 		pos[i] = robot.Pos.Add(robot.Vel.Mul(seconds))
 	}
@@ -124,40 +123,48 @@ func task1(args []string) error {
 
 	a, b, c, d := countQuadrant(pos, width, height)
 
-	fmt.Printf("%d * %d * %d * %d = %d\n", a, b, c, d, a*b*c*d)
-
-	return nil
+	return solver.Int(a * b * c * d), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-var directions = []utils.Vec{
-	{X: -1},
-	{X: +1},
-	{Y: -1},
-	{Y: +1},
+// spread returns n² times the variance of the coordinates, robots forming a picture are closer
+// together than randomly placed ones.
+func spread(coords []int) int {
+	sum, sumSq := 0, 0
+	for _, c := range coords {
+		sum += c
+		sumSq += c * c
+	}
+	return len(coords)*sumSq - sum*sum
 }
 
-func scoreMap(pos []utils.Vec) int {
-	score := 0
+// densest returns the second in [0, size) in which the coordinates are closest together. The
+// coordinates repeat after size seconds.
+func densest(pos, vel []int, size int) int {
+	coords := make([]int, len(pos))
 
-	for _, p := range pos {
-		for _, d := range directions {
-			if slices.Contains(pos, p.Add(d)) {
-				score++
-			}
+	best, bestSeconds := -1, 0
+	for seconds := range size {
+		for i := range pos {
+			coords[i] = mathx.Mod(pos[i]+vel[i]*seconds, size)
+		}
+
+		if s := spread(coords); best < 0 || s < best {
+			best = s
+			bestSeconds = seconds
 		}
 	}
 
-	return score
+	return bestSeconds
 }
 
-func printMap(pos []utils.Vec, width, height int) {
+func printMap(pos []geom.Vec, width, height int) {
 	for y := range height {
 		for x := range width {
-			if slices.Contains(pos, utils.Vec{X: x, Y: y}) {
+			if slices.Contains(pos, geom.Vec{X: x, Y: y}) {
 				fmt.Print("X")
 			} else {
 				fmt.Print(".")
@@ -167,41 +174,33 @@ func printMap(pos []utils.Vec, width, height int) {
 	}
 }
 
-func task2(args []string) error {
-	robots, err := utils.ReadLinesTransform(args[0], parseRobot)
+func task2(args []string) (solver.Answer, error) {
+	robots, err := parse.ReadLinesTransform(args[0], parseRobot)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	width := 101
-	height := 103
-	// width := 11
-	// height := 7
-
-	pos := make([]utils.Vec, len(robots))
-
-	maxScore := 0
-	maxScoreSeconds := 0
-	for seconds := range 10000 {
-		for i, robot := range robots {
-			pos[i] = robot.Pos.Add(robot.Vel.Mul(seconds))
-		}
-
-		for i, p := range pos {
-			pos[i] = mapToRoom(width, height, p)
-		}
-
-		score := scoreMap(pos)
-		if score > maxScore {
-			maxScore = score
-			maxScoreSeconds = seconds
+	width, height, err := roomSize(args)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-			fmt.Printf("Seconds: %d; Score: %d\n", seconds, score)
-			printMap(pos, width, height)
-		}
+	// the x coordinates repeat every width seconds and the y coordinates every height seconds,
+	// find the time each axis is most compact and combine them
+	posX, velX := make([]int, len(robots)), make([]int, len(robots))
+	posY, velY := make([]int, len(robots)), make([]int, len(robots))
+	for i, robot := range robots {
+		posX[i], velX[i] = robot.Pos.X, robot.Vel.X
+		posY[i], velY[i] = robot.Pos.Y, robot.Vel.Y
 	}
 
-	fmt.Printf("Score: %d at %d\n", maxScore, maxScoreSeconds)
+	seconds, _, ok := mathx.CRT(
+		[]int{densest(posX, velX, width), densest(posY, velY, height)},
+		[]int{width, height},
+	)
+	if !ok {
+		return solver.Answer{}, errors.New("the axes are never compact at the same time")
+	}
 
-	return nil
+	return solver.Int(seconds), nil
 }
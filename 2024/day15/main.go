@@ -1,36 +1,17 @@
-package main
+package day15
 
 import (
 	"bufio"
 	"fmt"
 	"os"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/grid"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 15, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -38,29 +19,28 @@ func main() {
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 var (
-	Left  = utils.Vec{X: -1}
-	Right = utils.Vec{X: +1}
+	Left  = geom.Vec{X: -1}
+	Right = geom.Vec{X: +1}
 )
 
-var dirs = map[byte]utils.Vec{
+var dirs = map[byte]geom.Vec{
 	'^': {Y: -1},
 	'v': {Y: +1},
 	'<': Left,
 	'>': Right,
 }
 
-func vecToGPS(pos utils.Vec) int {
+func vecToGPS(pos geom.Vec) int {
 	return pos.Y*100 + pos.X
 }
 
 type Map struct {
-	size utils.Vec
-	data map[utils.Vec]byte
+	data grid.Grid[byte]
 }
 
 func (m Map) SumCoords() int {
 	sum := 0
-	for pos, val := range m.data {
+	for pos, val := range m.data.All() {
 		if val != 'O' {
 			continue
 		}
@@ -70,7 +50,7 @@ func (m Map) SumCoords() int {
 	return sum
 }
 
-func (m Map) Move(pos utils.Vec, command byte) utils.Vec {
+func (m Map) Move(pos geom.Vec, command byte) geom.Vec {
 	dir := dirs[command]
 
 	freeSpace, ok := m.CanPush(pos, dir)
@@ -79,24 +59,24 @@ func (m Map) Move(pos utils.Vec, command byte) utils.Vec {
 	}
 
 	newPos := pos.Add(dir)
-	if m.data[newPos] == 'O' {
-		delete(m.data, newPos)
-		m.data[freeSpace] = 'O'
+	if m.data.At(newPos) == 'O' {
+		m.data.Set(newPos, '.')
+		m.data.Set(freeSpace, 'O')
 	}
 
 	return newPos
 }
 
-func (m Map) CanPush(pos, dir utils.Vec) (utils.Vec, bool) {
+func (m Map) CanPush(pos, dir geom.Vec) (geom.Vec, bool) {
 	for {
 		pos = pos.Add(dir)
-		val := m.data[pos]
+		val := m.data.At(pos)
 
 		switch val {
 		case 'O':
 			continue
 		case '#':
-			return utils.Vec{}, false
+			return geom.Vec{}, false
 		default:
 			return pos, true
 		}
@@ -104,52 +84,42 @@ func (m Map) CanPush(pos, dir utils.Vec) (utils.Vec, bool) {
 }
 
 func (m Map) Print() {
-	for y := 0; y < m.size.Y; y++ {
-		for x := 0; x < m.size.X; x++ {
-			pos := utils.Vec{X: x, Y: y}
-			if b, ok := m.data[pos]; ok {
-				fmt.Print(string(b))
-			} else {
-				fmt.Print(" ")
-			}
-		}
-		fmt.Println()
-	}
+	fmt.Println(m.data)
 }
 
-func ReadMapAndCommands(name string) (Map, utils.Vec, []byte, error) {
+func ReadMapAndCommands(name string) (Map, geom.Vec, []byte, error) {
 	f, err := os.Open(name)
 	if err != nil {
-		return Map{}, utils.Vec{}, nil, err
+		return Map{}, geom.Vec{}, nil, err
 	}
 	defer f.Close()
 
 	s := bufio.NewScanner(f)
 
-	y := 0
-	m := make(map[utils.Vec]byte)
-	size := utils.Vec{}
-	start := utils.Vec{}
+	var lines []string
+	width := 0
 	for s.Scan() {
 		if s.Text() == "" {
 			break
 		}
 
-		for x, b := range s.Bytes() {
-			if b == '.' {
-				continue
-			}
+		lines = append(lines, s.Text())
+		width = max(width, len(s.Text()))
+	}
+
+	m := grid.NewFilled(width, len(lines), byte('.'))
+	start := geom.Vec{}
+	for y, line := range lines {
+		for x, b := range []byte(line) {
+			pos := geom.Vec{X: x, Y: y}
 
 			if b == '@' {
-				start = utils.Vec{X: x, Y: y}
+				start = pos
 				continue
 			}
 
-			m[utils.Vec{X: x, Y: y}] = b
+			m.Set(pos, b)
 		}
-
-		y++
-		size.X = max(size.X, len(s.Bytes()))
 	}
 
 	var cmds []byte
@@ -157,14 +127,13 @@ func ReadMapAndCommands(name string) (Map, utils.Vec, []byte, error) {
 		cmds = append(cmds, s.Bytes()...)
 	}
 
-	size.Y = y
-	return Map{size: size, data: m}, start, cmds, s.Err()
+	return Map{data: m}, start, cmds, s.Err()
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	warehouse, start, commands, err := ReadMapAndCommands(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	// warehouse.Print()
@@ -175,9 +144,7 @@ func task1(args []string) error {
 		// warehouse.Print()
 	}
 
-	fmt.Printf("Sum: %d\n", warehouse.SumCoords())
-
-	return nil
+	return solver.Int(warehouse.SumCoords()), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -188,31 +155,27 @@ func (m Map) Fat() Map {
 	right := dirs['>']
 
 	m2 := Map{
-		size: utils.Vec{
-			X: m.size.X * 2,
-			Y: m.size.Y,
-		},
-		data: make(map[utils.Vec]byte, len(m.data)*2),
+		data: grid.NewFilled(m.data.Width()*2, m.data.Height(), byte('.')),
 	}
 
-	for pos, val := range m.data {
-		newPosA := utils.Vec{X: pos.X * 2, Y: pos.Y}
+	for pos, val := range m.data.All() {
+		newPosA := geom.Vec{X: pos.X * 2, Y: pos.Y}
 		newPosB := newPosA.Add(right)
 
 		switch val {
 		case 'O':
-			m2.data[newPosA] = '['
-			m2.data[newPosB] = ']'
+			m2.data.Set(newPosA, '[')
+			m2.data.Set(newPosB, ']')
 		case '#':
-			m2.data[newPosA] = '#'
-			m2.data[newPosB] = '#'
+			m2.data.Set(newPosA, '#')
+			m2.data.Set(newPosB, '#')
 		}
 	}
 
 	return m2
 }
 
-func (m Map) MoveFat(pos utils.Vec, command byte) utils.Vec {
+func (m Map) MoveFat(pos geom.Vec, command byte) geom.Vec {
 	dir := dirs[command]
 
 	newPos := pos.Add(dir)
@@ -224,9 +187,9 @@ func (m Map) MoveFat(pos utils.Vec, command byte) utils.Vec {
 	return newPos
 }
 
-func (m Map) PushFat(pos, dir utils.Vec) {
-	val, ok := m.data[pos]
-	if !ok {
+func (m Map) PushFat(pos, dir geom.Vec) {
+	val := m.data.At(pos)
+	if val == '.' {
 		return
 	}
 
@@ -235,8 +198,8 @@ func (m Map) PushFat(pos, dir utils.Vec) {
 	// left and right movement
 	if dir == Left || dir == Right {
 		m.PushFat(newPos, dir)
-		delete(m.data, pos)
-		m.data[newPos] = val
+		m.data.Set(pos, '.')
+		m.data.Set(newPos, val)
 
 		return
 	}
@@ -245,33 +208,33 @@ func (m Map) PushFat(pos, dir utils.Vec) {
 	switch val {
 	case '[':
 		m.PushFat(newPos, dir)
-		delete(m.data, pos)
-		m.data[newPos] = '['
+		m.data.Set(pos, '.')
+		m.data.Set(newPos, '[')
 
 		pos = pos.Add(Right)
 		newPos = newPos.Add(Right)
 
 		m.PushFat(newPos, dir)
-		delete(m.data, pos)
-		m.data[newPos] = ']'
+		m.data.Set(pos, '.')
+		m.data.Set(newPos, ']')
 
 	case ']':
 		m.PushFat(newPos, dir)
-		delete(m.data, pos)
-		m.data[newPos] = ']'
+		m.data.Set(pos, '.')
+		m.data.Set(newPos, ']')
 
 		pos = pos.Add(Left)
 		newPos = newPos.Add(Left)
 
 		m.PushFat(newPos, dir)
-		delete(m.data, pos)
-		m.data[newPos] = '['
+		m.data.Set(pos, '.')
+		m.data.Set(newPos, '[')
 	}
 }
 
-func (m Map) CanPushFat(pos, dir utils.Vec) bool {
-	val, ok := m.data[pos]
-	if !ok {
+func (m Map) CanPushFat(pos, dir geom.Vec) bool {
+	val, ok := m.data.Get(pos)
+	if !ok || val == '.' {
 		return true
 	}
 	if val == '#' {
@@ -308,7 +271,7 @@ func (m Map) CanPushFat(pos, dir utils.Vec) bool {
 
 func (m Map) SumCoordsFat() int {
 	sum := 0
-	for pos, val := range m.data {
+	for pos, val := range m.data.All() {
 		if val != '[' {
 			continue
 		}
@@ -318,25 +281,19 @@ func (m Map) SumCoordsFat() int {
 	return sum
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	warehouse, pos, commands, err := ReadMapAndCommands(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	warehouse = warehouse.Fat()
-	pos = utils.Vec{X: pos.X * 2, Y: pos.Y}
+	pos = geom.Vec{X: pos.X * 2, Y: pos.Y}
 
 	for _, command := range commands {
 		pos = warehouse.MoveFat(pos, command)
 	}
 
-	elapsed := time.Since(start)
-
 	// warehouse.Print()
-	fmt.Printf("Sum: %d (%s)\n", warehouse.SumCoordsFat(), elapsed)
-
-	return nil
+	return solver.Int(warehouse.SumCoordsFat()), nil
 }
@@ -1,40 +1,21 @@
-package main
+package day16
 
 import (
-	"container/heap"
+	"errors"
 	"fmt"
-	"os"
-	"slices"
-	"time"
-
-	"github.com/noxer/aoc/2024/utils"
+	"iter"
+
+	"github.com/noxer/aoc/lib/collections"
+	"github.com/noxer/aoc/lib/dot"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/grid"
+	"github.com/noxer/aoc/lib/search"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	// task1([]string{"input.txt"})
-	// return
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 16, task1, task2)
+	solver.RegisterGraph(2024, 16, graph)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -42,434 +23,209 @@ func main() {
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 var (
-	West      = utils.Vec{X: -1}
-	East      = utils.Vec{X: +1}
-	North     = utils.Vec{Y: -1}
-	South     = utils.Vec{Y: +1}
-	dirs      = [4]utils.Vec{West, East, North, South}
-	turnScore = map[Turn]int{
-		{West, North}: 1000,
-		{North, East}: 1000,
-		{East, South}: 1000,
-		{South, West}: 1000,
-
-		{North, West}: 1000,
-		{East, North}: 1000,
-		{South, East}: 1000,
-		{West, South}: 1000,
-
-		{North, South}: 2000,
-		{South, North}: 2000,
-		{East, West}:   2000,
-		{West, East}:   2000,
+	West  = geom.Vec{X: -1}
+	East  = geom.Vec{X: +1}
+	North = geom.Vec{Y: -1}
+	South = geom.Vec{Y: +1}
+	turns = map[geom.Vec][2]geom.Vec{
+		West:  {North, South},
+		East:  {North, South},
+		North: {West, East},
+		South: {West, East},
 	}
 )
 
-type Turn struct {
-	From, To utils.Vec
-}
-
 type Maze struct {
-	data  map[utils.Vec]byte
-	size  utils.Vec
-	start utils.Vec
-	end   utils.Vec
+	data  grid.Grid[byte]
+	start geom.Vec
+	end   geom.Vec
 }
 
-func (m *Maze) FindStartAndEnd() {
-	for pos, val := range m.data {
-		switch val {
-		case 'S':
-			m.start = pos
-			delete(m.data, pos)
-		case 'E':
-			m.end = pos
-			delete(m.data, pos)
-		}
-	}
-}
-
-func (m Maze) Get(pos utils.Vec) byte {
-	return m.data[pos]
-}
-
-func (m Maze) Wall(pos utils.Vec) bool {
-	return m.Get(pos) == '#'
-}
-
-func (m Maze) MarkNodes() {
-	for pos, val := range m.Iterate() {
-		if val != 0 {
-			continue
-		}
-
-		r := m.Wall(pos.Add(East))
-		l := m.Wall(pos.Add(West))
-		u := m.Wall(pos.Add(North))
-		d := m.Wall(pos.Add(South))
-
-		if l && r && !u && !d || !l && !r && u && d {
-			continue
-		}
-
-		m.data[pos] = '+'
+func loadMaze(name string) (Maze, error) {
+	data, err := grid.Read(name)
+	if err != nil {
+		return Maze{}, err
 	}
-}
-
-type Edge struct {
-	Cost int
-	End  *Node
-}
-
-type Node struct {
-	ID    int
-	Edges [4]Edge
-}
 
-func (m Maze) Graph() map[utils.Vec]*Node {
-	nodes := make(map[utils.Vec]*Node)
+	m := Maze{data: data}
 
-	id := 1
-	for pos := range m.FilterIterator('+') {
-		nodes[pos] = &Node{ID: id}
-		id++
+	var ok bool
+	if m.start, ok = grid.Find(data, 'S'); !ok {
+		return Maze{}, errors.New("maze has no start")
 	}
-
-	for pos, node := range nodes {
-		node.Edges = m.FindEdges(nodes, pos)
+	if m.end, ok = grid.Find(data, 'E'); !ok {
+		return Maze{}, errors.New("maze has no end")
 	}
 
-	return nodes
+	return m, nil
 }
 
-func (m Maze) FindEdges(nodes map[utils.Vec]*Node, pos utils.Vec) [4]Edge {
-	var result [4]Edge
-
-	for i, dir := range dirs {
-		nextPos := pos.Add(dir)
-		if m.Wall(nextPos) {
-			continue
-		}
-
-		count := 1
-		for m.Get(nextPos) != '+' {
-			nextPos = nextPos.Add(dir)
-			count++
-		}
-
-		result[i] = Edge{
-			Cost: count,
-			End:  nodes[nextPos],
-		}
-	}
-
-	return result
+func (m Maze) Wall(pos geom.Vec) bool {
+	b, ok := m.data.Get(pos)
+	return !ok || b == '#'
 }
 
-func (m Maze) Iterate() func(func(utils.Vec, byte) bool) {
-	return func(yield func(utils.Vec, byte) bool) {
-		for y := range m.size.Y {
-			for x := range m.size.X {
-				vec := utils.Vec{X: x, Y: y}
-				if !yield(vec, m.data[vec]) {
-					return
-				}
-			}
-		}
-	}
+type Reindeer struct {
+	Pos       geom.Vec
+	Direction geom.Vec
 }
 
-func (m Maze) FilterIterator(filter byte) func(func(utils.Vec) bool) {
-	return func(yield func(utils.Vec) bool) {
-		for pos, val := range m.Iterate() {
-			if val != filter {
-				continue
-			}
-
-			if !yield(pos) {
+// Moves returns the states a reindeer can reach: step forward or turn by 90 degrees.
+func (m Maze) Moves(r Reindeer) iter.Seq[Reindeer] {
+	return func(yield func(Reindeer) bool) {
+		if next := r.Pos.Add(r.Direction); !m.Wall(next) {
+			if !yield(Reindeer{Pos: next, Direction: r.Direction}) {
 				return
 			}
 		}
 
-	}
-}
-
-func (m Maze) Print() {
-	for y := range m.size.Y {
-		for x := range m.size.X {
-			if b, ok := m.data[utils.Vec{X: x, Y: y}]; ok {
-				fmt.Print(string(b))
-			} else {
-				fmt.Print(".")
+		for _, dir := range turns[r.Direction] {
+			if !yield(Reindeer{Pos: r.Pos, Direction: dir}) {
+				return
 			}
 		}
-		fmt.Println()
 	}
 }
 
-type Reindeer struct {
-	Node      *Node
-	Direction utils.Vec
-	Seen      []int
-	Score     int
-}
-
-func (r Reindeer) String() string {
-	return fmt.Sprintf("Reindeer with Score %d at Node %d, looking %v, seen: %v", r.Score, r.Node.ID, r.Direction, r.Seen)
-}
-
-type ReindeerHeap []Reindeer
-
-func (r *ReindeerHeap) Len() int {
-	return len(*r)
+func moveCost(from, to Reindeer) int {
+	if from.Direction != to.Direction {
+		return 1000
+	}
+	return 1
 }
 
-func (r *ReindeerHeap) Less(i, j int) bool {
-	return (*r)[i].Score < (*r)[j].Score
+func (m Maze) AtEnd(r Reindeer) bool {
+	return r.Pos == m.end
 }
 
-func (r *ReindeerHeap) Swap(i, j int) {
-	(*r)[i], (*r)[j] = (*r)[j], (*r)[i]
-}
+func task1(args []string) (solver.Answer, error) {
+	m, err := loadMaze(args[0])
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-func (r *ReindeerHeap) Push(x any) {
-	rd := x.(Reindeer)
-	*r = append(*r, rd)
-}
+	result := search.Dijkstra(Reindeer{Pos: m.start, Direction: East}, m.Moves, moveCost, m.AtEnd)
+	if !result.Found {
+		return solver.Answer{}, errors.New("no path to the end")
+	}
 
-func (r *ReindeerHeap) Pop() any {
-	rd := (*r)[r.Len()-1]
-	*r = (*r)[:r.Len()-1]
-	return rd
+	return solver.Int(result.Dist[result.Goal]), nil
 }
 
-func (r Reindeer) Move() []Reindeer {
-	var results []Reindeer
-
-	r.Seen = append(r.Seen, r.Node.ID)
-
-	for i, edge := range r.Node.Edges {
-		if edge.End == nil {
-			continue
-		}
-
-		if slices.Contains(r.Seen, edge.End.ID) {
-			continue
-		}
-
-		score := turnScore[Turn{r.Direction, dirs[i]}]
-		reindeer := Reindeer{
-			Node:      edge.End,
-			Direction: dirs[i],
-			Seen:      append([]int(nil), r.Seen...),
-			Score:     r.Score + score + edge.Cost,
-		}
+///////////////////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////////
 
-		results = append(results, reindeer)
+// BestTiles returns the tiles which are part of at least one of the best paths.
+func (m Maze) BestTiles() (collections.Set[geom.Vec], bool) {
+	dag := search.AllShortest(Reindeer{Pos: m.start, Direction: East}, m.Moves, moveCost, m.AtEnd)
+	if len(dag.Goals) == 0 {
+		return nil, false
 	}
 
-	return results
-}
+	tiles := collections.Set[geom.Vec]{}
+	for r := range dag.States(dag.Goals...) {
+		tiles.Put(r.Pos)
+	}
 
-type Tuple[S, T comparable] struct {
-	A S
-	B T
+	return tiles, true
 }
 
-func task1(args []string) error {
-	data, size, err := utils.ReadMapWithSize(args[0], '.')
+func task2(args []string) (solver.Answer, error) {
+	m, err := loadMaze(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	m := Maze{
-		data: data,
-		size: size,
+	tiles, ok := m.BestTiles()
+	if !ok {
+		return solver.Answer{}, errors.New("no path to the end")
 	}
 
-	// m.Print()
-	m.FindStartAndEnd()
-	// m.Print()
-	m.MarkNodes()
-	// m.Print()
-
-	graph := m.Graph()
-	startNode := graph[m.start]
-	endNode := graph[m.end]
-
-	reindeers := &ReindeerHeap{{Node: startNode, Direction: East}}
-	heap.Init(reindeers)
-
-	seen := make(map[Tuple[int, utils.Vec]]int)
-
-	score := 999999999999999999
-	for reindeers.Len() > 0 {
-		fmt.Printf("Length: %d\n", reindeers.Len())
-
-		reindeer := heap.Pop(reindeers).(Reindeer)
-
-		if reindeer.Score > score {
-			fmt.Printf("Score %d >= %d, killing reindeer...\n", reindeer.Score, score)
-			continue
-		}
-
-		if reindeer.Node.ID == endNode.ID {
-			fmt.Printf("Reindeer found end with score: %d\n", reindeer.Score)
-			score = min(score, reindeer.Score)
-			continue
-		}
-
-		for _, rd := range reindeer.Move() {
-			if best, ok := seen[Tuple[int, utils.Vec]{rd.Node.ID, rd.Direction}]; ok {
-				if rd.Score < best {
-					seen[Tuple[int, utils.Vec]{rd.Node.ID, rd.Direction}] = rd.Score
-					heap.Push(reindeers, rd)
-				}
-			} else {
-				seen[Tuple[int, utils.Vec]{rd.Node.ID, rd.Direction}] = rd.Score
-				heap.Push(reindeers, rd)
-			}
-		}
-	}
-
-	fmt.Printf("Score: %d\n", score)
-
-	return nil
+	return solver.Int(len(tiles)), nil
 }
 
-///////////////////////////////////////////////////////////////////////////////////////////////////
-///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
-	data, size, err := utils.ReadMapWithSize(args[0], '.')
-	if err != nil {
-		return err
+// Junction reports whether the maze branches at the position (or ends in a dead end).
+func (m Maze) Junction(pos geom.Vec) bool {
+	if pos == m.start || pos == m.end {
+		return true
 	}
 
-	start := time.Now()
-
-	m := Maze{
-		data: data,
-		size: size,
+	open := 0
+	for _, dir := range geom.Directions {
+		if !m.Wall(pos.Add(dir)) {
+			open++
+		}
 	}
+	return open != 2
+}
 
-	// m.Print()
-	m.FindStartAndEnd()
-	// m.Print()
-	m.MarkNodes()
-	// m.Print()
-
-	graph := m.Graph()
-	startNode := graph[m.start]
-	endNode := graph[m.end]
-
-	reindeers := &ReindeerHeap{{Node: startNode, Direction: East}}
-	heap.Init(reindeers)
-
-	seen := make(map[Tuple[int, utils.Vec]]int)
-
-	score := 999999999999999999
-	scoreSeens := make([][]int, 0, 20)
-	for reindeers.Len() > 0 {
-		// fmt.Printf("Length: %d\n", reindeers.Len())
+func nodeID(pos geom.Vec) string {
+	return fmt.Sprintf("%d,%d", pos.X, pos.Y)
+}
 
-		reindeer := heap.Pop(reindeers).(Reindeer)
+// Graph connects the junctions of the maze by the corridors between them. Corridors consisting
+// only of highlighted tiles are highlighted.
+func (m Maze) Graph(highlight collections.Set[geom.Vec]) *dot.Graph {
+	g := dot.New("maze", false)
 
-		if reindeer.Score > score {
-			// fmt.Printf("Score %d >= %d, killing reindeer...\n", reindeer.Score, score)
+	for pos, b := range m.data.All() {
+		if b == '#' || !m.Junction(pos) {
 			continue
 		}
 
-		if reindeer.Node.ID == endNode.ID {
-			// fmt.Printf("Reindeer found end with score: %d\n", reindeer.Score)
+		n := g.Node(nodeID(pos), "")
+		n.Highlight = highlight.Has(pos)
+		switch pos {
+		case m.start:
+			n.Label = "S"
+		case m.end:
+			n.Label = "E"
+		}
 
-			if reindeer.Score < score {
-				scoreSeens = scoreSeens[:1]
-				scoreSeens[0] = reindeer.Seen
-				score = reindeer.Score
-			} else if reindeer.Score == score {
-				scoreSeens = append(scoreSeens, reindeer.Seen)
+		// follow every corridor to the next junction, only towards the right and down to add
+		// each corridor once
+		for _, dir := range geom.Directions {
+			last, current := pos, pos.Add(dir)
+			if m.Wall(current) {
+				continue
 			}
 
-			continue
-		}
-
-		for _, rd := range reindeer.Move() {
-			if best, ok := seen[Tuple[int, utils.Vec]{rd.Node.ID, rd.Direction}]; ok {
-				if rd.Score <= best {
-					seen[Tuple[int, utils.Vec]{rd.Node.ID, rd.Direction}] = rd.Score
-					heap.Push(reindeers, rd)
+			length := 1
+			lit := highlight.Has(pos) && highlight.Has(current)
+			for !m.Junction(current) {
+				for _, d := range geom.Directions {
+					if next := current.Add(d); next != last && !m.Wall(next) {
+						last, current = current, next
+						break
+					}
 				}
-			} else {
-				seen[Tuple[int, utils.Vec]{rd.Node.ID, rd.Direction}] = rd.Score
-				heap.Push(reindeers, rd)
+				length++
+				lit = lit && highlight.Has(current)
 			}
-		}
-	}
 
-	// fmt.Println(scoreSeens)
-
-	elapsed := time.Since(start)
-
-	fmt.Printf("Score: %d (%s)\n", countTiles(graph, scoreSeens, endNode.ID), elapsed)
-
-	return nil
-}
+			if current.Y < pos.Y || current.Y == pos.Y && current.X <= pos.X {
+				continue
+			}
 
-func searchNode(graph map[utils.Vec]*Node, nodeID int) *Node {
-	for _, node := range graph {
-		if node.ID == nodeID {
-			return node
+			e := g.Edge(nodeID(pos), nodeID(current), fmt.Sprint(length))
+			e.Highlight = lit
 		}
 	}
 
-	return nil
+	return g
 }
 
-func countTiles(graph map[utils.Vec]*Node, seens [][]int, endNodeID int) int {
-	seenEdge := make(map[Tuple[int, int]]bool)
-	seenNode := make(map[int]bool)
-	score := 0
-
-	for _, seen := range seens {
-		for i, startID := range seen {
-			endID := endNodeID
-			if i+1 < len(seen) {
-				endID = seen[i+1]
-			}
-
-			if seenEdge[Tuple[int, int]{startID, endID}] {
-				continue
-			}
-
-			start := searchNode(graph, startID)
-			for _, edge := range start.Edges {
-				if edge.End != nil && edge.End.ID == endID {
-					score += edge.Cost
-
-					if seenNode[endID] {
-						score--
-					} else {
-						seenNode[endID] = true
-					}
-
-					seenEdge[Tuple[int, int]{startID, endID}] = true
-					seenEdge[Tuple[int, int]{endID, startID}] = true
-					break
-				}
-			}
-		}
+// graph exports the maze with the tiles on the best paths highlighted.
+func graph(args []string) (dot.Grapher, error) {
+	m, err := loadMaze(args[0])
+	if err != nil {
+		return nil, err
 	}
 
-	return score + 1 // add start tile
-}
+	tiles, _ := m.BestTiles()
 
-// sOO
-// o O
-// o O
-// o O
-// oOO
-// o
-// o
-// e
+	return m.Graph(tiles), nil
+}
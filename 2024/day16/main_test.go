@@ -0,0 +1,157 @@
+package day16
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/noxer/aoc/lib/collections"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/grid"
+	"github.com/noxer/aoc/lib/search"
+)
+
+// generateMaze builds a maze of the size of the puzzle input. It carves a random spanning tree and
+// then knocks out some more walls, so that like in the puzzle there are many paths to the end.
+func generateMaze(size int) Maze {
+	rnd := rand.New(rand.NewPCG(16, 2024))
+	data := grid.NewFilled(size, size, byte('#'))
+
+	stack := []geom.Vec{{X: 1, Y: size - 2}}
+	data.Set(stack[0], '.')
+	for len(stack) > 0 {
+		pos := stack[len(stack)-1]
+
+		var options []geom.Vec
+		for _, dir := range geom.Directions {
+			next := pos.Add(dir.Mul(2))
+			if b, ok := data.Get(next); ok && b == '#' && next.X > 0 && next.Y > 0 && next.X < size-1 && next.Y < size-1 {
+				options = append(options, dir)
+			}
+		}
+
+		if len(options) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		dir := options[rnd.IntN(len(options))]
+		data.Set(pos.Add(dir), '.')
+		data.Set(pos.Add(dir.Mul(2)), '.')
+		stack = append(stack, pos.Add(dir.Mul(2)))
+	}
+
+	for range size * size / 20 {
+		pos := geom.Vec{X: 1 + rnd.IntN(size-2), Y: 1 + rnd.IntN(size-2)}
+		data.Set(pos, '.')
+	}
+
+	return Maze{
+		data:  data,
+		start: geom.Vec{X: 1, Y: size - 2},
+		end:   geom.Vec{X: size - 2, Y: 1},
+	}
+}
+
+type scoredReindeer struct {
+	Reindeer
+	cost int
+}
+
+// duplicatePush is Dijkstra's algorithm the way 2024 day 16 used to do it: instead of lowering the
+// priority of a queued state, the state is pushed again and stale entries are skipped when popped.
+func (m Maze) duplicatePush() int {
+	dist := map[Reindeer]int{}
+	prev := map[Reindeer]Reindeer{}
+	open := collections.NewHeap(func(r scoredReindeer) int { return r.cost })
+	open.Push(scoredReindeer{Reindeer: Reindeer{Pos: m.start, Direction: East}})
+
+	for open.Len() > 0 {
+		r := open.Pop()
+		if best, ok := dist[r.Reindeer]; ok && best < r.cost {
+			continue
+		}
+		if m.AtEnd(r.Reindeer) {
+			return r.cost
+		}
+
+		for n := range m.Moves(r.Reindeer) {
+			c := r.cost + moveCost(r.Reindeer, n)
+			if best, ok := dist[n]; ok && best <= c {
+				continue
+			}
+
+			dist[n] = c
+			prev[n] = r.Reindeer
+			open.Push(scoredReindeer{Reindeer: n, cost: c})
+		}
+	}
+
+	return -1
+}
+
+// indexedHeap is the same search with a decrease-key heap: a queued state gets its priority lowered
+// instead of being pushed again. Like search.Dijkstra it keeps the handle of each state in the dist
+// map and the costs in a slice indexed by handle.
+func (m Maze) indexedHeap() int {
+	start := Reindeer{Pos: m.start, Direction: East}
+
+	dist := map[Reindeer]int{}
+	prev := map[Reindeer]Reindeer{}
+	open := collections.NewIndexedHeap[Reindeer, int]()
+	dist[start] = int(open.Push(start, 0))
+	costs := []int{0}
+
+	for r, cost := range open.Drain() {
+		if m.AtEnd(r) {
+			return cost
+		}
+
+		for n := range m.Moves(r) {
+			c := cost + moveCost(r, n)
+			it, ok := dist[n]
+			if ok && costs[it] <= c {
+				continue
+			}
+
+			prev[n] = r
+			if ok && open.Contains(collections.Handle(it)) {
+				costs[it] = c
+				open.Update(collections.Handle(it), c)
+			} else {
+				dist[n] = int(open.Push(n, c))
+				costs = append(costs, c)
+			}
+		}
+	}
+
+	return -1
+}
+
+func TestDuplicatePush(t *testing.T) {
+	m := generateMaze(141)
+
+	want := search.Dijkstra(Reindeer{Pos: m.start, Direction: East}, m.Moves, moveCost, m.AtEnd)
+	if !want.Found {
+		t.Fatal("search.Dijkstra didn't find the end")
+	}
+
+	if a, b := m.indexedHeap(), m.duplicatePush(); a != want.Dist[want.Goal] || b != want.Dist[want.Goal] {
+		t.Errorf("IndexedHeap found %d, duplicate push found %d, want %d", a, b, want.Dist[want.Goal])
+	}
+}
+
+func BenchmarkIndexedHeap(b *testing.B) {
+	m := generateMaze(141)
+
+	for range b.N {
+		m.indexedHeap()
+	}
+}
+
+func BenchmarkDuplicatePush(b *testing.B) {
+	m := generateMaze(141)
+
+	for range b.N {
+		m.duplicatePush()
+	}
+}
@@ -1,51 +1,69 @@
-package main
+package day17
 
 import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/debug"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
+func init() {
+	solver.Register(2024, 17, task1, task2)
+	solver.RegisterDebug(2024, 17, debugCPU)
+}
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+///////////////////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////////
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+type Opcode int
+
+const (
+	Adv Opcode = iota // A = A >> combo
+	Bxl               // B = B ^ literal
+	Bst               // B = combo & 7
+	Jnz               // if A != 0 jump to literal
+	Bxc               // B = B ^ C
+	Out               // output combo & 7
+	Bdv               // B = A >> combo
+	Cdv               // C = A >> combo
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
+var mnemonics = [...]string{"adv", "bxl", "bst", "jnz", "bxc", "out", "bdv", "cdv"}
+
+func (op Opcode) String() string {
+	if op < 0 || int(op) >= len(mnemonics) {
+		return "???"
 	}
+	return mnemonics[op]
 }
 
-///////////////////////////////////////////////////////////////////////////////////////////////////
-///////////////////////////////////////////////////////////////////////////////////////////////////
-///////////////////////////////////////////////////////////////////////////////////////////////////
+// Combo reports whether the operand of the instruction is a combo operand.
+func (op Opcode) Combo() bool {
+	switch op {
+	case Adv, Bst, Out, Bdv, Cdv:
+		return true
+	}
+	return false
+}
 
 type CPU struct {
 	A, B, C int
 	PC      int
 	Memory  []int
 	Output  []int
+	// Trace receives a line with the registers after every instruction if set.
+	Trace io.Writer
+
+	initial [3]int // registers as loaded, for Restart
 }
 
 func (cpu *CPU) loadCombo(combo int) int {
@@ -66,52 +84,58 @@ func (cpu *CPU) loadCombo(combo int) int {
 }
 
 func (cpu *CPU) Step() bool {
-	if cpu.PC < 0 || cpu.PC >= len(cpu.Memory) {
+	if cpu.PC < 0 || cpu.PC+1 >= len(cpu.Memory) {
 		return false
 	}
 
-	inst := cpu.Memory[cpu.PC]
+	pc := cpu.PC
+	inst := Opcode(cpu.Memory[cpu.PC])
 	oper := cpu.Memory[cpu.PC+1]
 
 	switch inst {
-	case 0: // adv
+	case Adv:
 		oper = cpu.loadCombo(oper)
 		denom := 1 << oper
 		cpu.A /= denom
 
-	case 1: // bxl
+	case Bxl:
 		cpu.B ^= oper
 
-	case 2: // bst
+	case Bst:
 		oper = cpu.loadCombo(oper)
 		cpu.B = oper & 7
 
-	case 3: // jnz
+	case Jnz:
 		if cpu.A == 0 {
 			break
 		}
 
 		cpu.PC = oper - 2
 
-	case 4: // bxc
+	case Bxc:
 		cpu.B ^= cpu.C
 
-	case 5: // out
+	case Out:
 		oper = cpu.loadCombo(oper)
 		cpu.Output = append(cpu.Output, oper&7)
 
-	case 6: // bdv
+	case Bdv:
 		oper = cpu.loadCombo(oper)
 		denom := 1 << oper
 		cpu.B = cpu.A / denom
 
-	case 7: // cdv
+	case Cdv:
 		oper = cpu.loadCombo(oper)
 		denom := 1 << oper
 		cpu.C = cpu.A / denom
 	}
 
 	cpu.PC += 2
+
+	if cpu.Trace != nil {
+		fmt.Fprintf(cpu.Trace, "%-28s A=%o B=%o C=%o\n", decode(cpu.Memory, pc), cpu.A, cpu.B, cpu.C)
+	}
+
 	return true
 }
 
@@ -160,95 +184,309 @@ func loadCPU(name string) (*CPU, error) {
 		return nil, errors.New("unexpected end")
 	}
 
-	cpu.Memory = utils.ParseInts(strings.TrimPrefix(s.Text(), "Program: "), ",")
+	cpu.Memory = parse.ParseInts(strings.TrimPrefix(s.Text(), "Program: "), ",")
+	cpu.initial = [3]int{cpu.A, cpu.B, cpu.C}
 
 	return cpu, nil
 }
 
-func task1(args []string) error {
+// task1 runs the program. Pass -trace after the input file to log every instruction or -disasm
+// to print the disassembled program to stderr.
+func task1(args []string) (solver.Answer, error) {
 	cpu, err := loadCPU(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	for cpu.Step() {
+	if len(args) > 1 {
+		switch args[1] {
+		case "-trace":
+			cpu.Trace = os.Stderr
+		case "-disasm":
+			for _, inst := range Disassemble(cpu.Memory) {
+				fmt.Fprintln(os.Stderr, inst)
+			}
+		}
 	}
 
-	fmt.Println(cpu.Output)
+	cpu.Run()
 
-	return nil
+	return solver.String(cpu.OutputString()), nil
 }
 
-///////////////////////////////////////////////////////////////////////////////////////////////////
-///////////////////////////////////////////////////////////////////////////////////////////////////
-///////////////////////////////////////////////////////////////////////////////////////////////////
+// OutputString joins the output with commas.
+func (cpu *CPU) OutputString() string {
+	output := make([]string, len(cpu.Output))
+	for i, n := range cpu.Output {
+		output[i] = strconv.Itoa(n)
+	}
+	return strings.Join(output, ",")
+}
+
+// Position, Next, State and Restart make the CPU steppable in the debugger.
+func (cpu *CPU) Position() int {
+	return cpu.PC
+}
+
+func (cpu *CPU) Next() string {
+	if cpu.PC < 0 || cpu.PC+1 >= len(cpu.Memory) {
+		return "halted"
+	}
+	return decode(cpu.Memory, cpu.PC)
+}
+
+func (cpu *CPU) State() []debug.Register {
+	return []debug.Register{
+		{Name: "A", Value: strconv.Itoa(cpu.A)},
+		{Name: "B", Value: strconv.Itoa(cpu.B)},
+		{Name: "C", Value: strconv.Itoa(cpu.C)},
+		{Name: "PC", Value: strconv.Itoa(cpu.PC)},
+		{Name: "out", Value: cpu.OutputString()},
+	}
+}
+
+func (cpu *CPU) Restart(values map[string]int) error {
+	regs := cpu.initial
+	for name, v := range values {
+		switch name {
+		case "A":
+			regs[0] = v
+		case "B":
+			regs[1] = v
+		case "C":
+			regs[2] = v
+		default:
+			return fmt.Errorf("unknown register %q", name)
+		}
+	}
 
-func task2(args []string) error {
+	cpu.Reset(regs[0])
+	cpu.B, cpu.C = regs[1], regs[2]
+
+	return nil
+}
+
+func debugCPU(args []string) (debug.Steppable, error) {
 	cpu, err := loadCPU(args[0])
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return cpu, nil
+}
 
-	// pow := math.Pow(8, 15)*6 +
-	// 	math.Pow(8, 14)*5 +
-	// 	math.Pow(8, 12)*18
+type Instruction struct {
+	Addr    int
+	Op      Opcode
+	Operand int
+}
+
+func comboName(combo int) string {
+	switch combo {
+	case 4:
+		return "A"
+	case 5:
+		return "B"
+	case 6:
+		return "C"
+	case 7:
+		return "<invalid>"
+	}
+	return strconv.Itoa(combo)
+}
 
-	// math.Pow(8, 12) +
-	// math.Pow(8, 11) +
-	// math.Pow(8, 10)*2 +
-	// math.Pow(8, 9)*7
+func (i Instruction) String() string {
+	operand := strconv.Itoa(i.Operand)
+	if i.Op.Combo() {
+		operand = comboName(i.Operand)
+	}
 
-	// match := []int{6, 6, 6, 4, 5, 1, 3, 0}
+	var effect string
+	switch i.Op {
+	case Adv:
+		effect = "A = A >> " + operand
+	case Bxl:
+		effect = "B = B ^ " + operand
+	case Bst:
+		effect = "B = " + operand + " & 7"
+	case Jnz:
+		effect = "if A != 0 goto " + operand
+	case Bxc:
+		effect = "B = B ^ C"
+	case Out:
+		effect = "out " + operand + " & 7"
+	case Bdv:
+		effect = "B = A >> " + operand
+	case Cdv:
+		effect = "C = A >> " + operand
+	}
 
-	start := time.Now()
-	result := trySolve(cpu, 0)
-	elapsed := time.Since(start)
+	return fmt.Sprintf("%02d: %s %-2s ; %s", i.Addr, i.Op, operand, effect)
+}
 
-	fmt.Printf("Result: %d (%s)\n", result, elapsed)
+// Disassemble decodes the program into instructions.
+func Disassemble(memory []int) []Instruction {
+	insts := make([]Instruction, 0, len(memory)/2)
+	for pc := 0; pc+1 < len(memory); pc += 2 {
+		insts = append(insts, Instruction{
+			Addr:    pc,
+			Op:      Opcode(memory[pc]),
+			Operand: memory[pc+1],
+		})
+	}
+	return insts
+}
 
-	return nil
+func decode(memory []int, pc int) string {
+	return Instruction{Addr: pc, Op: Opcode(memory[pc]), Operand: memory[pc+1]}.String()
 }
 
-func trySolve(cpu *CPU, a int) int {
-	for b := range 8 {
-		cpu.Reset(a + b)
-		cpu.Run()
+///////////////////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CheckLoop verifies that the program is a single loop which outputs one value and shifts A by 3
+// per iteration, with B and C written before they are read. Every iteration then only depends on
+// A, which makes each output a function of a single octal digit of A (and the higher digits).
+func CheckLoop(memory []int) error {
+	if len(memory) == 0 {
+		return errors.New("program is empty")
+	}
+
+	insts := Disassemble(memory)
+	if len(insts) == 0 || len(memory)%2 != 0 {
+		return errors.New("program has an odd length")
+	}
+
+	last := insts[len(insts)-1]
+	if last.Op != Jnz || last.Operand != 0 {
+		return errors.New("program doesn't end with jnz 0")
+	}
 
-		// fmt.Printf("Trying %v\n", cpu.Output)
+	shifts, outputs := 0, 0
+	written := map[int]bool{}
+
+	read := func(combo int) error {
+		if (combo == 5 || combo == 6) && !written[combo] {
+			return fmt.Errorf("register %s is read before it is written", comboName(combo))
+		}
+		return nil
+	}
 
-		if hasSuffix(cpu.Memory, cpu.Output) {
-			if slices.Equal(cpu.Memory, cpu.Output) {
-				return a + b
+	for _, inst := range insts[:len(insts)-1] {
+		if inst.Op.Combo() {
+			if inst.Operand == 7 {
+				return fmt.Errorf("%s uses the invalid combo operand 7", inst)
 			}
+			if err := read(inst.Operand); err != nil {
+				return err
+			}
+		}
 
-			res := trySolve(cpu, (a+b)*8)
-			if res > 0 {
-				return res
+		switch inst.Op {
+		case Adv:
+			if inst.Operand != 3 {
+				return fmt.Errorf("%s doesn't shift A by 3", inst)
+			}
+			shifts++
+		case Jnz:
+			return fmt.Errorf("%s jumps inside the loop", inst)
+		case Out:
+			outputs++
+		case Bxl:
+			if err := read(5); err != nil {
+				return err
 			}
+		case Bxc:
+			if err := read(5); err != nil {
+				return err
+			}
+			if err := read(6); err != nil {
+				return err
+			}
+		}
+
+		switch inst.Op {
+		case Bxl, Bst, Bxc, Bdv:
+			written[5] = true
+		case Cdv:
+			written[6] = true
 		}
 	}
 
-	return -1
+	if shifts != 1 {
+		return fmt.Errorf("A is shifted %d times per iteration, expected once", shifts)
+	}
+	if outputs != 1 {
+		return fmt.Errorf("%d outputs per iteration, expected one", outputs)
+	}
+
+	return nil
 }
 
-func hasSuffix(sl, suffix []int) bool {
-	if len(suffix) > len(sl) {
-		return false
+// FirstOutput runs the program with A set to a until it outputs the first value.
+func (cpu *CPU) FirstOutput(a int) (int, bool) {
+	cpu.Reset(a)
+	for len(cpu.Output) == 0 {
+		if !cpu.Step() {
+			return 0, false
+		}
+	}
+	return cpu.Output[0], true
+}
+
+// FindQuine searches the smallest A which makes the program output itself. It works backward
+// through the program: the last output only depends on the highest octal digit of A, the one
+// before on the two highest digits and so on.
+func FindQuine(cpu *CPU) (int, bool) {
+	var search func(a, i int) (int, bool)
+	search = func(a, i int) (int, bool) {
+		if i < 0 {
+			return a, true
+		}
+
+		for d := range 8 {
+			next := a<<3 | d
+			// A = 0 would end the program before the output
+			if next == 0 {
+				continue
+			}
+
+			if out, ok := cpu.FirstOutput(next); !ok || out != cpu.Memory[i] {
+				continue
+			}
+
+			if res, ok := search(next, i-1); ok {
+				return res, true
+			}
+		}
+
+		return 0, false
 	}
 
-	diff := len(sl) - len(suffix)
-	return slices.Equal(sl[diff:], suffix)
+	return search(0, len(cpu.Memory)-1)
 }
 
-// func reverseProgram(output []int) int {
-// 	slices.Reverse(output)
+func task2(args []string) (solver.Answer, error) {
+	cpu, err := loadCPU(args[0])
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-// 	var a, b, c int
+	if err = CheckLoop(cpu.Memory); err != nil {
+		return solver.Answer{}, fmt.Errorf("program doesn't have the expected shape: %w", err)
+	}
 
-// 	a = 48
+	a, ok := FindQuine(cpu)
+	if !ok {
+		return solver.Answer{}, errors.New("no value for A reproduces the program")
+	}
 
-// 	for _, n := range output {
-// 		b = n ^ 5
+	// make sure the whole program agrees
+	cpu.Reset(a)
+	cpu.Run()
+	if !slices.Equal(cpu.Output, cpu.Memory) {
+		return solver.Answer{}, fmt.Errorf("A = %d outputs %v", a, cpu.Output)
+	}
 
-// 	}
-// }
+	return solver.Int(a), nil
+}
@@ -1,44 +1,26 @@
-package main
+package day18
 
 import (
+	"errors"
 	"fmt"
-	"math"
-	"os"
+	"iter"
 	"sort"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/lib/search"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 18, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-var Directions = []utils.Vec{
+var Directions = []geom.Vec{
 	{X: -1}, // left / west
 	{Y: +1}, // down / south
 	{X: +1}, // right / east
@@ -46,147 +28,103 @@ var Directions = []utils.Vec{
 }
 
 type MemorySpace struct {
-	data map[utils.Vec]int
-	size utils.Vec
+	data map[geom.Vec]int
+	size geom.Vec
 }
 
-func (ms MemorySpace) Contains(pos utils.Vec) bool {
+func (ms MemorySpace) Contains(pos geom.Vec) bool {
 	return pos.X >= 0 && pos.X < ms.size.X && pos.Y >= 0 && pos.Y < ms.size.Y
 }
 
-func (ms MemorySpace) FromSlice(bytes []utils.Vec) {
+func (ms MemorySpace) FromSlice(bytes []geom.Vec) {
 	for i, v := range bytes {
 		ms.data[v] = i
 	}
 }
 
-func (ms MemorySpace) FindPath(from, to utils.Vec) int {
-	seen := make(map[utils.Vec]int)
-	return ms.findPath(from, to, seen, 1) - 1
-}
-
-func (ms MemorySpace) findPath(pos, to utils.Vec, seen map[utils.Vec]int, steps int) int {
-	// found the exit
-	if pos == to {
-		return 1
-	}
+// Free returns the neighbouring positions which are inside the memory space and not corrupted.
+func (ms MemorySpace) Free(pos geom.Vec) iter.Seq[geom.Vec] {
+	return func(yield func(geom.Vec) bool) {
+		for _, dir := range geom.Directions {
+			next := pos.Add(dir)
 
-	// outside of memory space
-	if !ms.Contains(pos) {
-		return -1
-	}
+			// outside of memory space
+			if !ms.Contains(next) {
+				continue
+			}
 
-	// memory corruption
-	if _, ok := ms.data[pos]; ok {
-		return -1
-	}
+			// memory corruption
+			if _, ok := ms.data[next]; ok {
+				continue
+			}
 
-	// we were here before
-	if score, ok := seen[pos]; ok && score <= steps {
-		return -1
-	}
-	seen[pos] = steps
-
-	best := math.MaxInt
-	for _, dir := range utils.Directions {
-		newPos := pos.Add(dir)
-		res := ms.findPath(newPos, to, seen, steps+1)
-		if res >= 0 {
-			best = min(best, res)
+			if !yield(next) {
+				return
+			}
 		}
 	}
+}
 
-	if best != math.MaxInt {
-		return best + 1
-	} else {
+func (ms MemorySpace) FindPath(from, to geom.Vec) int {
+	result := search.BFS(from, ms.Free, func(pos geom.Vec) bool { return pos == to })
+	if !result.Found {
 		return -1
 	}
+
+	return result.Dist[to]
 }
 
-func parseByte(line string) utils.Vec {
-	vec := utils.Vec{}
+func parseByte(line string) geom.Vec {
+	vec := geom.Vec{}
 	fmt.Sscanf(line, "%d,%d", &vec.X, &vec.Y)
 	return vec
 }
 
-func task1(args []string) error {
-	bytes, err := utils.ReadLinesTransform(args[0], parseByte)
+// task1 finds the shortest path through a 71x71 memory space after 1024 bytes have fallen. The size
+// and the number of bytes can be passed after the input file.
+func task1(args []string) (solver.Answer, error) {
+	bytes, err := parse.ReadLinesTransform(args[0], parseByte)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	fmt.Println(bytes)
+	size, err := parse.IntArg(args, 1, 71)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	count, err := parse.IntArg(args, 2, 1024)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	count = min(count, len(bytes))
 
 	ms := MemorySpace{
-		data: make(map[utils.Vec]int, len(bytes)),
-		size: utils.Vec{
-			X: 71,
-			Y: 71,
+		data: make(map[geom.Vec]int, len(bytes)),
+		size: geom.Vec{
+			X: size,
+			Y: size,
 		},
 	}
-	ms.FromSlice(bytes[:1024])
-
-	start := utils.Vec{X: 0, Y: 0}
-	end := utils.Vec{X: 70, Y: 70}
+	ms.FromSlice(bytes[:count])
 
-	begin := time.Now()
+	start := geom.Vec{X: 0, Y: 0}
+	end := geom.Vec{X: size - 1, Y: size - 1}
 
 	length := ms.FindPath(start, end)
 
-	elapsed := time.Since(begin)
-
-	fmt.Printf("Path length: %d (%s)\n", length, elapsed)
-
-	return nil
+	return solver.Int(length), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-var seen = make(map[utils.Vec]int)
-
-func (ms MemorySpace) FindPath2(from, to utils.Vec) []utils.Vec {
-	clear(seen)
-	return ms.findPath2(from, to, seen, 1)
-}
-
-func (ms MemorySpace) findPath2(pos, to utils.Vec, seen map[utils.Vec]int, steps int) []utils.Vec {
-	// found the exit
-	if pos == to {
-		res := make([]utils.Vec, 1, steps)
-		res[0] = pos
-		return res
-	}
-
-	// outside of memory space
-	if !ms.Contains(pos) {
-		return nil
-	}
-
-	// memory corruption
-	if _, ok := ms.data[pos]; ok {
-		return nil
-	}
-
-	// we were here before
-	if score, ok := seen[pos]; ok && score <= steps {
-		return nil
-	}
-	seen[pos] = steps
-
-	for _, dir := range utils.Directions {
-		newPos := pos.Add(dir)
-		res := ms.findPath2(newPos, to, seen, steps+1)
-		if len(res) > 0 {
-			return append(res, pos)
-		}
-	}
-
-	return nil
+func (ms MemorySpace) FindPath2(from, to geom.Vec) []geom.Vec {
+	result := search.BFS(from, ms.Free, func(pos geom.Vec) bool { return pos == to })
+	return result.Path(to)
 }
 
-func (ms MemorySpace) CheckPaths(paths [][]utils.Vec) bool {
+func (ms MemorySpace) CheckPaths(paths [][]geom.Vec) bool {
 outer:
 	for _, path := range paths {
 		for _, location := range path {
@@ -201,28 +139,32 @@ outer:
 	return false
 }
 
-func task2(args []string) error {
-	bytes, err := utils.ReadLinesTransform(args[0], parseByte)
+// task2 finds the first byte blocking the path. It accepts the size like task1.
+func task2(args []string) (solver.Answer, error) {
+	bytes, err := parse.ReadLinesTransform(args[0], parseByte)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	// fmt.Println(bytes)
 
+	size, err := parse.IntArg(args, 1, 71)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
 	ms := MemorySpace{
-		data: make(map[utils.Vec]int, len(bytes)),
-		size: utils.Vec{
-			X: 71,
-			Y: 71,
+		data: make(map[geom.Vec]int, len(bytes)),
+		size: geom.Vec{
+			X: size,
+			Y: size,
 		},
 	}
 
-	start := utils.Vec{X: 0, Y: 0}
-	end := utils.Vec{X: 70, Y: 70}
+	start := geom.Vec{X: 0, Y: 0}
+	end := geom.Vec{X: size - 1, Y: size - 1}
 
-	begin := time.Now()
-
-	paths := make([][]utils.Vec, 0, 100)
+	paths := make([][]geom.Vec, 0, 100)
 
 	lastI := 0
 	i, _ := sort.Find(len(bytes), func(i int) int {
@@ -249,13 +191,8 @@ func task2(args []string) error {
 		return 1
 	})
 	if i < 0 || i >= len(bytes) {
-		// fmt.Println("Couldn't find blocking byte...")
-		return nil
+		return solver.Answer{}, errors.New("couldn't find blocking byte")
 	}
 
-	elapsed := time.Since(begin)
-
-	fmt.Printf("Found result: %d,%d (%s)\n", bytes[i].X, bytes[i].Y, elapsed)
-
-	return nil
+	return solver.String(fmt.Sprintf("%d,%d", bytes[i].X, bytes[i].Y)), nil
 }
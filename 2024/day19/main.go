@@ -1,44 +1,23 @@
-package main
+package day19
 
 import (
 	"bufio"
-	"fmt"
 	"os"
 	"strings"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/collections"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 19, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func parseTowels(name string) (utils.Set[string], []string, error) {
+func parseTowels(name string) (collections.Set[string], []string, error) {
 	f, err := os.Open(name)
 	if err != nil {
 		return nil, nil, err
@@ -50,7 +29,7 @@ func parseTowels(name string) (utils.Set[string], []string, error) {
 	if !s.Scan() {
 		return nil, nil, s.Err()
 	}
-	available := utils.SetFromSlice(strings.Split(s.Text(), ", "))
+	available := collections.SetFromSlice(strings.Split(s.Text(), ", "))
 
 	var patterns []string
 	for s.Scan() {
@@ -65,7 +44,7 @@ func parseTowels(name string) (utils.Set[string], []string, error) {
 	return available, patterns, s.Err()
 }
 
-func findCombination(towels utils.Set[string], pattern string) bool {
+func findCombination(towels collections.Set[string], pattern string) bool {
 	if pattern == "" {
 		return true
 	}
@@ -87,10 +66,10 @@ func findCombination(towels utils.Set[string], pattern string) bool {
 	return false
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	towels, patterns, err := parseTowels(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	counter := 0
@@ -100,16 +79,14 @@ func task1(args []string) error {
 		}
 	}
 
-	fmt.Printf("Found %d valid patterns\n", counter)
-
-	return nil
+	return solver.Int(counter), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func findAllCombinations(towels utils.Set[string], pattern string, maxTowel int, cache map[string]int) int {
+func findAllCombinations(towels collections.Set[string], pattern string, maxTowel int, cache map[string]int) int {
 	if pattern == "" {
 		return 1
 	}
@@ -141,14 +118,12 @@ func findAllCombinations(towels utils.Set[string], pattern string, maxTowel int,
 	return sum
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	towels, patterns, err := parseTowels(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	maxTowel := 0
 	for towel := range towels {
 		maxTowel = max(maxTowel, len(towel))
@@ -163,9 +138,5 @@ func task2(args []string) error {
 		counter += findAllCombinations(towels, pattern, maxTowel, cache)
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Found %d valid patterns in %s\n", counter, elapsed)
-
-	return nil
+	return solver.Int(counter), nil
 }
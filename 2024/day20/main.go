@@ -1,36 +1,18 @@
-package main
+package day20
 
 import (
 	"fmt"
-	"os"
-	"slices"
-	"time"
+	"iter"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/grid"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/lib/search"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 20, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -38,86 +20,83 @@ func main() {
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 type Maze struct {
-	data  map[utils.Vec]byte
-	size  utils.Vec
-	start utils.Vec
-	end   utils.Vec
-	times []utils.Vec
+	data   grid.Grid[byte]
+	start  geom.Vec
+	end    geom.Vec
+	times  []geom.Vec
+	timeAt grid.Grid[int]
 }
 
 func (m *Maze) FindStartAndEnd() {
-	for pos, val := range m.data {
+	for pos, val := range m.data.All() {
 		switch val {
 		case 'S':
 			m.start = pos
-			delete(m.data, pos)
+			m.data.Set(pos, '.')
 		case 'E':
 			m.end = pos
-			delete(m.data, pos)
+			m.data.Set(pos, '.')
 		}
 	}
 }
 
-func (m Maze) Get(pos utils.Vec) byte {
-	return m.data[pos]
+func (m Maze) Get(pos geom.Vec) byte {
+	return m.data.At(pos)
 }
 
-func (m Maze) Wall(pos utils.Vec) bool {
+func (m Maze) Wall(pos geom.Vec) bool {
 	return m.Get(pos) == '#'
 }
 
 func (m Maze) Print() {
-	for y := range m.size.Y {
-		for x := range m.size.X {
-			pos := utils.Vec{X: x, Y: y}
-
-			if b, ok := m.data[pos]; ok {
-				fmt.Print(string(b))
-			} else {
-				idx := slices.Index(m.times, pos)
-				if idx >= 0 {
-					fmt.Print(idx % 10)
-				} else {
-					fmt.Print(".")
-				}
-			}
+	for pos, b := range m.data.All() {
+		if pos.X == 0 && pos.Y > 0 {
+			fmt.Println()
 		}
-		fmt.Println()
-	}
-}
 
-func (m *Maze) PopulateTimes() {
-	last := m.start
-	pos := m.start
-	time := 0
-	for {
-		m.times = append(m.times, pos)
-
-		if pos == m.end {
-			break
+		if idx := m.timeAt.At(pos); idx >= 0 && b == '.' {
+			fmt.Print(idx % 10)
+		} else {
+			fmt.Print(string(b))
 		}
+	}
+	fmt.Println()
+}
 
-		for _, dir := range utils.Directions {
-			next := pos.Add(dir)
-			if next == last || m.Wall(next) {
+func (m Maze) Track(pos geom.Vec) iter.Seq[geom.Vec] {
+	return func(yield func(geom.Vec) bool) {
+		for next, b := range m.data.Neighbors4(pos) {
+			if b == '#' {
 				continue
 			}
 
-			last = pos
-			pos = next
-			time++
-			break
+			if !yield(next) {
+				return
+			}
 		}
 	}
 }
 
+func (m *Maze) PopulateTimes() {
+	result := search.BFS(m.start, m.Track, func(pos geom.Vec) bool { return pos == m.end })
+
+	m.times = result.Path(m.end)
+	m.timeAt = grid.NewFilled(m.data.Width(), m.data.Height(), -1)
+	for time, pos := range m.times {
+		m.timeAt.Set(pos, time)
+	}
+}
+
 type Shortcut struct {
-	Start, End utils.Vec
+	Start, End geom.Vec
 	Saves      int
 }
 
-func (m Maze) GetTime(pos utils.Vec) int {
-	idx := slices.Index(m.times, pos)
+func (m Maze) GetTime(pos geom.Vec) int {
+	idx, ok := m.timeAt.Get(pos)
+	if !ok {
+		return 0
+	}
 	return max(idx, 0)
 }
 
@@ -125,7 +104,7 @@ func (m Maze) FindShortcuts() []Shortcut {
 	var shortcuts []Shortcut
 
 	for start, pos := range m.times {
-		for _, dir := range utils.Directions {
+		for _, dir := range geom.Directions {
 			next := pos.Add(dir)
 			if !m.Wall(next) {
 				continue
@@ -147,15 +126,21 @@ func (m Maze) FindShortcuts() []Shortcut {
 	return shortcuts
 }
 
-func task1(args []string) error {
-	data, size, err := utils.ReadMapWithSize(args[0], '.')
+// task1 counts the cheats saving at least 100 picoseconds. Another threshold can be passed after the
+// input file.
+func task1(args []string) (solver.Answer, error) {
+	data, err := grid.Read(args[0])
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	limit, err := parse.IntArg(args, 1, 100)
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	maze := Maze{
 		data: data,
-		size: size,
 	}
 	maze.FindStartAndEnd()
 
@@ -163,18 +148,16 @@ func task1(args []string) error {
 
 	shortcuts := maze.FindShortcuts()
 
-	fmt.Println(shortcuts)
+	// fmt.Println(shortcuts)
 
 	count := 0
 	for _, shortcut := range shortcuts {
-		if shortcut.Saves >= 100 {
+		if shortcut.Saves >= limit {
 			count++
 		}
 	}
 
-	fmt.Printf("Shortcuts >= 100ps: %d\n", count)
-
-	return nil
+	return solver.Int(count), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -182,10 +165,10 @@ func task1(args []string) error {
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 type FromTo struct {
-	From, To utils.Vec
+	From, To geom.Vec
 }
 
-func Distance(a, b utils.Vec) int {
+func Distance(a, b geom.Vec) int {
 	return (max(a.X, b.X) - min(a.X, b.X)) + (max(a.Y, b.Y) - min(a.Y, b.Y))
 }
 
@@ -212,27 +195,26 @@ func (m Maze) CountLongShortcuts(limit int) int {
 	return shortcuts
 }
 
-func task2(args []string) error {
-	data, size, err := utils.ReadMapWithSize(args[0], '.')
+// task2 counts the long cheats, it accepts the threshold like task1.
+func task2(args []string) (solver.Answer, error) {
+	data, err := grid.Read(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
+	limit, err := parse.IntArg(args, 1, 100)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
 	maze := Maze{
 		data: data,
-		size: size,
 	}
 	maze.FindStartAndEnd()
 
 	maze.PopulateTimes()
 
-	shortcuts := maze.CountLongShortcuts(100)
-
-	elapsed := time.Since(start)
-
-	fmt.Printf("Shortcuts >= 100ps: %d (%s)\n", shortcuts, elapsed)
+	shortcuts := maze.CountLongShortcuts(limit)
 
-	return nil
+	return solver.Int(shortcuts), nil
 }
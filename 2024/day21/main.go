@@ -1,38 +1,17 @@
-package main
+package day21
 
 import (
 	"bytes"
-	"fmt"
-	"os"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/geom"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 21, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -43,7 +22,7 @@ type Keys struct {
 	From, To byte
 }
 
-var numpadPositions = map[byte]utils.Vec{
+var numpadPositions = map[byte]geom.Vec{
 	'7': {X: 0, Y: 0},
 	'8': {X: 1, Y: 0},
 	'9': {X: 2, Y: 0},
@@ -133,7 +112,7 @@ func horizontalKeys(x int) []byte {
 	}
 }
 
-var dirpadPositions = map[byte]utils.Vec{
+var dirpadPositions = map[byte]geom.Vec{
 	'^': {X: 1, Y: 0},
 	'A': {X: 2, Y: 0},
 	'<': {X: 0, Y: 1},
@@ -199,10 +178,10 @@ func pressDirpadKey(last, current byte, level int) string {
 	return seqB
 }
 
-func task1(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task1(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := 0
@@ -215,9 +194,7 @@ func task1(args []string) error {
 		sum += num * len(seq)
 	}
 
-	fmt.Printf("Sum: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -343,14 +320,12 @@ func pressDirpadKey2(last, current byte, level int, cache map[CacheKey]int) int
 	return min(seqA, seqB)
 }
 
-func task2(args []string) error {
-	lines, err := utils.ReadLines(args[0])
+func task2(args []string) (solver.Answer, error) {
+	lines, err := parse.ReadLines(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	sum := 0
 
 	for _, line := range lines {
@@ -361,9 +336,5 @@ func task2(args []string) error {
 		sum += num * seq
 	}
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Sum: %d (%s)\n", sum, elapsed)
-
-	return nil
+	return solver.Int(sum), nil
 }
@@ -1,39 +1,17 @@
-package main
+package day22
 
 import (
 	"bytes"
-	"fmt"
 	"iter"
-	"os"
 	"slices"
 	"strconv"
-	"time"
 
-	"github.com/noxer/aoc/2024/utils"
+	"github.com/noxer/aoc/lib/parse"
+	"github.com/noxer/aoc/solver"
 )
 
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 22, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -61,13 +39,13 @@ func calculateRandom(n uint, rounds int) uint {
 	return n
 }
 
-func task1(args []string) error {
-	buyers, err := utils.ReadLinesTransform(args[0], func(line string) uint {
+func task1(args []string) (solver.Answer, error) {
+	buyers, err := parse.ReadLinesTransform(args[0], func(line string) uint {
 		i, _ := strconv.ParseUint(line, 10, 0)
 		return uint(i)
 	})
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	sum := 0
@@ -75,9 +53,7 @@ func task1(args []string) error {
 		sum += int(calculateRandom(buyer, 2000))
 	}
 
-	fmt.Printf("Sum: %d\n", sum)
-
-	return nil
+	return solver.Int(sum), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -116,17 +92,15 @@ func (r Random) IterateOffsets(n int) func(func(byte) bool) {
 	}
 }
 
-func task2(args []string) error {
-	buyers, err := utils.ReadLinesTransform(args[0], func(line string) uint {
+func task2(args []string) (solver.Answer, error) {
+	buyers, err := parse.ReadLinesTransform(args[0], func(line string) uint {
 		i, _ := strconv.ParseUint(line, 10, 0)
 		return uint(i)
 	})
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	start := time.Now()
-
 	sequences := make([][]byte, len(buyers))
 	prices := make([][]uint, len(buyers))
 	for i, buyer := range buyers {
@@ -158,11 +132,7 @@ func task2(args []string) error {
 
 	// fmt.Println(ps)
 
-	elapsed := time.Since(start)
-
-	fmt.Printf("Maximum price: %d (%s)\n", maxPrice, elapsed)
-
-	return nil
+	return solver.Int(int(maxPrice)), nil
 }
 
 func sumPrices(prices [][]uint, sequences [][]byte, sub []byte) uint {
@@ -1,4 +1,4 @@
-package main
+package day23
 
 import (
 	"bufio"
@@ -7,30 +7,14 @@ import (
 	"slices"
 	"sort"
 	"strings"
-)
-
-func main() {
-	var err error
-
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
 
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/lib/dot"
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 23, task1, task2)
+	solver.RegisterGraph(2024, 23, graph)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -71,6 +55,30 @@ func (n Network) GetComputer(name string) *Computer {
 	return c
 }
 
+func (n Network) Graph() *dot.Graph {
+	g := dot.New("network", false)
+
+	names := make([]string, 0, len(n.Computers))
+	for name := range n.Computers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g.Node(name, "")
+	}
+
+	for _, name := range names {
+		for _, peer := range n.Computers[name].Peers {
+			if name < peer {
+				g.Edge(name, peer, "")
+			}
+		}
+	}
+
+	return g
+}
+
 func (n Network) ParseConnection(line string) {
 	a, b, _ := strings.Cut(line, "-")
 	n.GetComputer(a).AddPeer(b)
@@ -139,22 +147,20 @@ func filterStartsWithT(triangles [][]string) [][]string {
 	return result
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	network := Network{
 		Computers: make(map[string]*Computer),
 	}
 
 	err := network.Parse(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	triangles := network.FindSetsOfThree()
 	triangles = filterStartsWithT(triangles)
 
-	fmt.Printf("Count of three connected computer triangles: %d\n", len(triangles))
-
-	return nil
+	return solver.Int(len(triangles)), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -327,25 +333,37 @@ func (n Network) FindBiggestClusterTheNextGeneration() []string {
 	return computers[0]
 }
 
-func task2(args []string) error {
+func task2(args []string) (solver.Answer, error) {
 	network := Network{
 		Computers: make(map[string]*Computer),
 	}
 
 	err := network.Parse(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
 	biggest := network.FindBiggestClusterTheNextGeneration()
-	fmt.Println(biggest)
 
 	sort.Strings(biggest)
 	password := strings.Join(biggest, ",")
 
-	fmt.Printf("Password: %s\n", password)
+	return solver.String(password), nil
+}
+
+// graph exports the network with the biggest cluster highlighted.
+func graph(args []string) (dot.Grapher, error) {
+	network := Network{
+		Computers: make(map[string]*Computer),
+	}
+
+	err := network.Parse(args[0])
+	if err != nil {
+		return nil, err
+	}
 
-	// fmt.Printf("Count of three connected computer triangles: %d\n", len(triangles))
+	g := network.Graph()
+	g.HighlightClique(network.FindBiggestClusterTheNextGeneration()...)
 
-	return nil
+	return g, nil
 }
@@ -1,34 +1,15 @@
-package main
+package day25
 
 import (
 	"bufio"
-	"fmt"
 	"os"
 	"slices"
-)
-
-func main() {
-	var err error
 
-	if len(os.Args) <= 1 {
-		fmt.Println("Missing argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "1":
-		err = task1(os.Args[2:])
-	case "2":
-		err = task2(os.Args[2:])
-	default:
-		fmt.Println("Invalid argument, please specify the task you want to execute (1 or 2).")
-		os.Exit(1)
-	}
+	"github.com/noxer/aoc/solver"
+)
 
-	if err != nil {
-		fmt.Printf("Error executing task %s: %s\n", os.Args[1], err)
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(2024, 25, task1, task2)
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -109,15 +90,12 @@ func keyFits(lock Lock, key Key) bool {
 	return true
 }
 
-func task1(args []string) error {
+func task1(args []string) (solver.Answer, error) {
 	locks, keys, err := parseLocksAndKeys(args[0])
 	if err != nil {
-		return err
+		return solver.Answer{}, err
 	}
 
-	fmt.Println(locks)
-	fmt.Println(keys)
-
 	count := 0
 	for _, lock := range locks {
 		for _, key := range keys {
@@ -127,15 +105,13 @@ func task1(args []string) error {
 		}
 	}
 
-	fmt.Printf("%d combinations\n", count)
-
-	return nil
+	return solver.Int(count), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func task2(args []string) error {
-	return nil
+func task2(args []string) (solver.Answer, error) {
+	return solver.Answer{}, nil
 }
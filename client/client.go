@@ -0,0 +1,77 @@
+// Package client talks to the Advent of Code website on behalf of a logged in user.
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL is the address of the Advent of Code website.
+const DefaultBaseURL = "https://adventofcode.com"
+
+const userAgent = "github.com/noxer/aoc"
+
+// Client authenticates against the website with the session cookie of a logged in user.
+type Client struct {
+	BaseURL string
+	Session string
+	HTTP    *http.Client
+}
+
+// New creates a client for the Advent of Code website using the given session cookie.
+func New(session string) *Client {
+	return &Client{
+		BaseURL: DefaultBaseURL,
+		Session: session,
+		HTTP:    http.DefaultClient,
+	}
+}
+
+// Fetch downloads the personal puzzle input of the user.
+func (c *Client) Fetch(ctx context.Context, year, day int) ([]byte, error) {
+	req, err := c.request(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(req)
+}
+
+func (c *Client) request(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Attempt is a single answer submitted to the website.
+type Attempt struct {
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+	// Until is the earliest time the next answer may be submitted.
+	Until time.Time `json:"until"`
+}
+
+// History records all submitted answers, so the same wrong answer is never submitted twice.
+type History struct {
+	name     string
+	Attempts []Attempt `json:"attempts"`
+}
+
+// LoadHistory reads the history from a JSON file. A missing file results in an empty history.
+func LoadHistory(name string) (*History, error) {
+	h := &History{name: name}
+
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", name, err)
+	}
+
+	return h, nil
+}
+
+// Save writes the history back to the file it was loaded from.
+func (h *History) Save() error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(h.name), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(h.name, data, 0o644)
+}
+
+// Record adds an attempt to the history.
+func (h *History) Record(a Attempt) {
+	h.Attempts = append(h.Attempts, a)
+}
+
+// Check returns an error if the answer must not be submitted at the given time: because it was
+// submitted before, the part is solved already, the website asked us to wait or the answer lies
+// outside the bounds learned from previous "too high" and "too low" responses.
+func (h *History) Check(year, day, part int, answer string, now time.Time) error {
+	value, numeric := new(big.Int).SetString(answer, 10)
+
+	var low, high *big.Int
+	for _, a := range h.Attempts {
+		if a.Year != year || a.Day != day || a.Part != part {
+			continue
+		}
+
+		if a.Until.After(now) {
+			return fmt.Errorf("please wait until %s before submitting again", a.Until.Format(time.TimeOnly))
+		}
+
+		if a.Verdict == Correct {
+			return fmt.Errorf("%d day %02d part %d is solved already", year, day, part)
+		}
+
+		// the website didn't judge these answers, they may be submitted again
+		if a.Verdict == TooSoon || a.Verdict == WrongLevel || a.Verdict == Unknown {
+			continue
+		}
+
+		if a.Answer == answer {
+			return fmt.Errorf("answer %s was submitted before: %s", answer, a.Verdict)
+		}
+
+		v, ok := new(big.Int).SetString(a.Answer, 10)
+		if !ok {
+			continue
+		}
+
+		switch a.Verdict {
+		case TooHigh:
+			if high == nil || v.Cmp(high) < 0 {
+				high = v
+			}
+		case TooLow:
+			if low == nil || v.Cmp(low) > 0 {
+				low = v
+			}
+		}
+	}
+
+	if !numeric {
+		return nil
+	}
+
+	if high != nil && value.Cmp(high) >= 0 {
+		return fmt.Errorf("answer %s is too high, it must be less than %s", answer, high)
+	}
+	if low != nil && value.Cmp(low) <= 0 {
+		return fmt.Errorf("answer %s is too low, it must be greater than %s", answer, low)
+	}
+
+	return nil
+}
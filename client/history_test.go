@@ -0,0 +1,98 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 12, 1, 6, 0, 0, 0, time.UTC)
+
+func attempt(part int, answer string, verdict Verdict) Attempt {
+	return Attempt{Year: 2024, Day: 1, Part: part, Answer: answer, Verdict: verdict, Time: start}
+}
+
+func TestHistoryCheck(t *testing.T) {
+	waiting := attempt(1, "100", TooHigh)
+	waiting.Until = start.Add(time.Minute)
+
+	h := &History{Attempts: []Attempt{
+		waiting,
+		attempt(1, "10", TooLow),
+		attempt(1, "50", Wrong),
+		attempt(1, "60", TooSoon),
+		attempt(1, "70", Unknown),
+		attempt(2, "1", WrongLevel),
+	}}
+	later := start.Add(2 * time.Minute)
+
+	tests := []struct {
+		name   string
+		part   int
+		answer string
+		now    time.Time
+		ok     bool
+	}{
+		{"wait", 1, "20", start, false},
+		{"wait is over", 1, "20", later, true},
+		{"wait for another part", 2, "20", start, true},
+		{"duplicate", 1, "50", later, false},
+		{"duplicate too soon", 1, "60", later, true},
+		{"duplicate unknown", 1, "70", later, true},
+		{"duplicate wrong level", 2, "1", later, true},
+		{"too high", 1, "100", later, false},
+		{"above too high", 1, "150", later, false},
+		{"too low", 1, "10", later, false},
+		{"below too low", 1, "5", later, false},
+		{"between bounds", 1, "99", later, true},
+		{"not a number", 1, "abc", later, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := h.Check(2024, 1, tt.part, tt.answer, tt.now)
+			if (err == nil) != tt.ok {
+				t.Errorf("Check(part %d, %s) = %v, want ok %t", tt.part, tt.answer, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestHistoryCheckSolved(t *testing.T) {
+	h := &History{Attempts: []Attempt{attempt(1, "42", Correct)}}
+
+	if err := h.Check(2024, 1, 1, "43", start); err == nil {
+		t.Error("Check accepted an answer for a solved part")
+	}
+	if err := h.Check(2024, 1, 2, "43", start); err != nil {
+		t.Errorf("Check rejected part 2 after part 1 was solved: %v", err)
+	}
+	if err := h.Check(2024, 2, 1, "43", start); err != nil {
+		t.Errorf("Check rejected another day: %v", err)
+	}
+}
+
+func TestHistorySave(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "aoc", "history.json")
+
+	h, err := LoadHistory(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Attempts) != 0 {
+		t.Fatalf("missing history has %d attempts", len(h.Attempts))
+	}
+
+	h.Record(attempt(1, "42", WrongLevel))
+	if err = h.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err = LoadHistory(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Attempts) != 1 || h.Attempts[0].Verdict != WrongLevel || !h.Attempts[0].Time.Equal(start) {
+		t.Errorf("loaded attempts = %+v", h.Attempts)
+	}
+}
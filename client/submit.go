@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is the reaction of the website to a submitted answer.
+type Verdict int
+
+const (
+	Unknown Verdict = iota
+	Correct
+	Wrong
+	TooHigh
+	TooLow
+	TooSoon
+	// WrongLevel is returned for a part which is solved already or whose previous part isn't
+	// solved yet.
+	WrongLevel
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Correct:
+		return "correct"
+	case Wrong:
+		return "wrong"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case TooSoon:
+		return "too soon"
+	case WrongLevel:
+		return "wrong level"
+	default:
+		return "unknown"
+	}
+}
+
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for c := Unknown; c <= WrongLevel; c++ {
+		if c.String() == string(text) {
+			*v = c
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid verdict %q", text)
+}
+
+// Response is the parsed answer of the website to a submission.
+type Response struct {
+	Verdict Verdict
+	// Wait is the time to wait before the next answer may be submitted.
+	Wait    time.Duration
+	Message string
+}
+
+// Submit posts the answer for part 1 or 2 of a puzzle.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (Response, error) {
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	}
+
+	req, err := c.request(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Response{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.do(req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	return ParseResponse(string(body)), nil
+}
+
+var (
+	articleRegexp = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegexp     = regexp.MustCompile(`<[^>]*>`)
+	waitRegexp    = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait`)
+	minuteRegexp  = regexp.MustCompile(`wait (\w+) minutes?`)
+)
+
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// ParseResponse extracts the verdict from the HTML page returned after submitting an answer.
+func ParseResponse(body string) Response {
+	msg := body
+	if m := articleRegexp.FindStringSubmatch(body); m != nil {
+		msg = m[1]
+	}
+	msg = strings.Join(strings.Fields(tagRegexp.ReplaceAllString(msg, "")), " ")
+
+	r := Response{
+		Message: msg,
+		Wait:    parseWait(msg),
+	}
+
+	switch {
+	case strings.Contains(msg, "That's the right answer"):
+		r.Verdict = Correct
+	case strings.Contains(msg, "You gave an answer too recently"):
+		r.Verdict = TooSoon
+	case strings.Contains(msg, "You don't seem to be solving the right level"):
+		r.Verdict = WrongLevel
+	case strings.Contains(msg, "That's not the right answer"):
+		r.Verdict = Wrong
+		if strings.Contains(msg, "your answer is too high") {
+			r.Verdict = TooHigh
+		} else if strings.Contains(msg, "your answer is too low") {
+			r.Verdict = TooLow
+		}
+	}
+
+	return r
+}
+
+func parseWait(msg string) time.Duration {
+	if m := waitRegexp.FindStringSubmatch(msg); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+
+	if m := minuteRegexp.FindStringSubmatch(msg); m != nil {
+		minutes, ok := numberWords[m[1]]
+		if !ok {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		return time.Duration(minutes) * time.Minute
+	}
+
+	return 0
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func page(article string) string {
+	return `<!DOCTYPE html><html><body><main><article><p>` + article + `</p></article></main></body></html>`
+}
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want Verdict
+		wait time.Duration
+	}{
+		{
+			name: "correct",
+			body: page(`That's the right answer! You are <span class="day-success">one gold star</span> closer.`),
+			want: Correct,
+		},
+		{
+			name: "too high",
+			body: page(`That's not the right answer; your answer is too high. Please wait one minute before trying again. <a href="/2024/day/1">[Return to Day 1]</a>`),
+			want: TooHigh,
+			wait: time.Minute,
+		},
+		{
+			name: "too low",
+			body: page(`That's not the right answer; your answer is too low. Please wait 5 minutes before trying again.`),
+			want: TooLow,
+			wait: 5 * time.Minute,
+		},
+		{
+			name: "wrong",
+			body: page(`That's not the right answer. If you're stuck, make sure you're using the full input data. Please wait one minute before trying again.`),
+			want: Wrong,
+			wait: time.Minute,
+		},
+		{
+			name: "too soon",
+			body: page(`You gave an answer too recently; you have to wait after submitting an answer before trying again. You have 1m 23s left to wait.`),
+			want: TooSoon,
+			wait: time.Minute + 23*time.Second,
+		},
+		{
+			name: "wrong level",
+			body: page(`You don't seem to be solving the right level. Did you already complete it?`),
+			want: WrongLevel,
+		},
+		{
+			name: "unknown",
+			body: `<html><body>Something unexpected</body></html>`,
+			want: Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ParseResponse(tt.body)
+			if r.Verdict != tt.want {
+				t.Errorf("Verdict = %s, want %s (message %q)", r.Verdict, tt.want, r.Message)
+			}
+			if r.Wait != tt.wait {
+				t.Errorf("Wait = %s, want %s", r.Wait, tt.wait)
+			}
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/3/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if c, err := r.Cookie("session"); err != nil || c.Value != "secret" {
+			http.Error(w, "not logged in", http.StatusBadRequest)
+			return
+		}
+		if r.PostFormValue("level") != "2" {
+			http.Error(w, "wrong level", http.StatusBadRequest)
+			return
+		}
+
+		if r.PostFormValue("answer") == "42" {
+			w.Write([]byte(page(`That's the right answer!`)))
+		} else {
+			w.Write([]byte(page(`That's not the right answer; your answer is too low. Please wait one minute before trying again.`)))
+		}
+	}))
+	defer srv.Close()
+
+	c := New("secret")
+	c.BaseURL = srv.URL
+	c.HTTP = srv.Client()
+
+	resp, err := c.Submit(context.Background(), 2024, 3, 2, "41")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Verdict != TooLow || resp.Wait != time.Minute {
+		t.Errorf("Submit(41) = %s, wait %s, want too low, wait 1m", resp.Verdict, resp.Wait)
+	}
+
+	resp, err = c.Submit(context.Background(), 2024, 3, 2, "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Verdict != Correct {
+		t.Errorf("Submit(42) = %s, want correct", resp.Verdict)
+	}
+
+	c.Session = "expired"
+	if _, err = c.Submit(context.Background(), 2024, 3, 2, "42"); err == nil {
+		t.Error("Submit with an invalid session succeeded")
+	}
+}
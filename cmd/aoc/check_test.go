@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/noxer/aoc/solver"
+)
+
+// TestAnswers runs the golden answer checks of "aoc check" for all registered days.
+func TestAnswers(t *testing.T) {
+	answers := make(map[int]solver.Answers)
+
+	for _, d := range solver.Days() {
+		if _, ok := answers[d.Year]; !ok {
+			a, err := solver.LoadAnswers(filepath.Join("..", "..", strconv.Itoa(d.Year), "answers.json"))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				t.Fatal(err)
+			}
+			answers[d.Year] = a
+		}
+
+		t.Run(fmt.Sprintf("%d/%02d", d.Year, d.Day), func(t *testing.T) {
+			missing := 0
+			results := solver.Check(d, answers[d.Year][d.Day])
+			for _, r := range results {
+				switch r.Status {
+				case solver.Fail, solver.Error:
+					t.Error(r)
+				case solver.Missing:
+					missing++
+					t.Log(r)
+				default:
+					t.Log(r)
+				}
+			}
+
+			if missing == len(results) {
+				t.Skip("no answers to compare")
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+// Import all puzzle packages so they register their solutions.
+import (
+	_ "github.com/noxer/aoc/2015/day01"
+	_ "github.com/noxer/aoc/2015/day02"
+	_ "github.com/noxer/aoc/2015/day03"
+	_ "github.com/noxer/aoc/2015/day04"
+	_ "github.com/noxer/aoc/2015/day05"
+	_ "github.com/noxer/aoc/2015/day06"
+	_ "github.com/noxer/aoc/2015/day07"
+	_ "github.com/noxer/aoc/2015/day08"
+	_ "github.com/noxer/aoc/2015/day09"
+	_ "github.com/noxer/aoc/2015/day10"
+	_ "github.com/noxer/aoc/2015/day11"
+	_ "github.com/noxer/aoc/2015/day12"
+	_ "github.com/noxer/aoc/2015/day13"
+	_ "github.com/noxer/aoc/2015/day14"
+	_ "github.com/noxer/aoc/2015/day15"
+	_ "github.com/noxer/aoc/2015/day16"
+	_ "github.com/noxer/aoc/2015/day17"
+	_ "github.com/noxer/aoc/2015/day18"
+	_ "github.com/noxer/aoc/2015/day19"
+	_ "github.com/noxer/aoc/2015/day20"
+	_ "github.com/noxer/aoc/2015/day21"
+	_ "github.com/noxer/aoc/2015/day22"
+	_ "github.com/noxer/aoc/2023/day18"
+	_ "github.com/noxer/aoc/2023/day19"
+	_ "github.com/noxer/aoc/2023/day20"
+	_ "github.com/noxer/aoc/2023/day21"
+	_ "github.com/noxer/aoc/2023/day22"
+	_ "github.com/noxer/aoc/2023/day23"
+	_ "github.com/noxer/aoc/2023/day24"
+	_ "github.com/noxer/aoc/2024/day01"
+	_ "github.com/noxer/aoc/2024/day02"
+	_ "github.com/noxer/aoc/2024/day03"
+	_ "github.com/noxer/aoc/2024/day04"
+	_ "github.com/noxer/aoc/2024/day05"
+	_ "github.com/noxer/aoc/2024/day06"
+	_ "github.com/noxer/aoc/2024/day07"
+	_ "github.com/noxer/aoc/2024/day08"
+	_ "github.com/noxer/aoc/2024/day09"
+	_ "github.com/noxer/aoc/2024/day10"
+	_ "github.com/noxer/aoc/2024/day11"
+	_ "github.com/noxer/aoc/2024/day12"
+	_ "github.com/noxer/aoc/2024/day13"
+	_ "github.com/noxer/aoc/2024/day14"
+	_ "github.com/noxer/aoc/2024/day15"
+	_ "github.com/noxer/aoc/2024/day16"
+	_ "github.com/noxer/aoc/2024/day17"
+	_ "github.com/noxer/aoc/2024/day18"
+	_ "github.com/noxer/aoc/2024/day19"
+	_ "github.com/noxer/aoc/2024/day20"
+	_ "github.com/noxer/aoc/2024/day21"
+	_ "github.com/noxer/aoc/2024/day22"
+	_ "github.com/noxer/aoc/2024/day23"
+	_ "github.com/noxer/aoc/2024/day24"
+	_ "github.com/noxer/aoc/2024/day25"
+)
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/noxer/aoc/lib/debug"
+	"github.com/noxer/aoc/solver"
+)
+
+var update = flag.Bool("update", false, "rewrite the expected transcripts")
+
+// TestDebugScripts runs the debugger scripts in testdata against the machines of the puzzles and compares the
+// transcripts with the .golden files.
+func TestDebugScripts(t *testing.T) {
+	tests := []struct {
+		name      string
+		year, day int
+		input     string
+	}{
+		{"2024-17", 2024, 17, "../../2024/day17/example2.txt"},
+		{"2023-20", 2023, 20, "../../2023/day20/easy.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, ok := solver.Lookup(tt.year, tt.day)
+			if !ok || day.Debug == nil {
+				t.Fatalf("%d day %d has no debugger", tt.year, tt.day)
+			}
+
+			m, err := day.Debug([]string{tt.input})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			script, err := os.Open(filepath.Join("testdata", "debug-"+tt.name+".script"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer script.Close()
+
+			var out bytes.Buffer
+			if err = debug.New(m, &out).Run(script); err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", "debug-"+tt.name+".golden")
+			if *update {
+				if err = os.WriteFile(golden, out.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(out.Bytes(), want) {
+				t.Errorf("transcript differs from %s:\n%s", golden, out.String())
+			}
+		})
+	}
+}
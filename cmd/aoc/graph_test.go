@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/noxer/aoc/lib/dot"
+	"github.com/noxer/aoc/solver"
+)
+
+// TestGraphStable exports the graphs of the example inputs several times. The output of
+// "aoc graph" must not depend on map iteration order.
+func TestGraphStable(t *testing.T) {
+	for _, d := range solver.Days() {
+		if d.Graph == nil {
+			continue
+		}
+
+		answers, err := solver.LoadAnswers(filepath.Join("..", "..", strconv.Itoa(d.Year), "answers.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		inputs := map[string]bool{}
+		for _, c := range answers[d.Day] {
+			if c.Input == "" || inputs[c.Input] {
+				continue
+			}
+			inputs[c.Input] = true
+
+			t.Run(fmt.Sprintf("%d/%02d/%s", d.Year, d.Day, filepath.Base(c.Input)), func(t *testing.T) {
+				var first []byte
+				for i := range 5 {
+					g, err := d.Graph([]string{c.Input})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					var out bytes.Buffer
+					if err = dot.Write(&out, g); err != nil {
+						t.Fatal(err)
+					}
+
+					if i == 0 {
+						first = out.Bytes()
+					} else if !bytes.Equal(out.Bytes(), first) {
+						t.Fatalf("export %d differs from the first one:\n%s\nfirst:\n%s", i+1, out.Bytes(), first)
+					}
+				}
+			})
+		}
+	}
+}
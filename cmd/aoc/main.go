@@ -0,0 +1,401 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/noxer/aoc/client"
+	"github.com/noxer/aoc/input"
+	"github.com/noxer/aoc/lib/debug"
+	"github.com/noxer/aoc/lib/dot"
+	"github.com/noxer/aoc/solver"
+)
+
+const usage = `Usage:
+  aoc run <year> <day> <part> [input [args...]]
+                                          execute a single part of a puzzle, the input defaults to
+                                          $AOC_INPUT_DIR/<year>/<day>.txt (or ~/.cache/aoc), "-" reads stdin
+  aoc list [year]                         list all available puzzles
+  aoc check [-root dir] [year [day]]      compare the answers with <root>/<year>/answers.json
+  aoc submit [-history file] <year> <day> <part> [input [args...]]
+                                          execute a single part of a puzzle and submit the answer,
+                                          requires AOC_SESSION
+  aoc graph [-o file] <year> <day> [input [args...]]
+                                          write the graph of a puzzle input in the DOT language
+  aoc debug [-script file] <year> <day> [input [args...]]
+                                          step through the machine a puzzle simulates, commands
+                                          are read from the script or stdin (try "help")`
+
+func main() {
+	if len(os.Args) <= 1 {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	var err error
+
+	switch os.Args[1] {
+	case "run":
+		err = run(os.Args[2:])
+	case "list":
+		err = list(os.Args[2:])
+	case "check":
+		err = check(os.Args[2:])
+	case "submit":
+		err = submit(os.Args[2:])
+	case "graph":
+		err = graph(os.Args[2:])
+	case "debug":
+		err = debugger(os.Args[2:])
+	default:
+		fmt.Printf("Invalid command %q.\n%s\n", os.Args[1], usage)
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Printf("Error executing %s: %s\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	_, _, _, err := solve(args)
+	return err
+}
+
+// solve executes the part of the puzzle given by the first three arguments and prints the answer.
+func solve(args []string) (solver.Day, int, solver.Answer, error) {
+	if len(args) < 3 {
+		return solver.Day{}, 0, solver.Answer{}, errors.New("missing arguments, please specify year, day and part")
+	}
+
+	day, err := lookup(args[0], args[1])
+	if err != nil {
+		return solver.Day{}, 0, solver.Answer{}, err
+	}
+
+	part, err := strconv.Atoi(args[2])
+	if err != nil {
+		return solver.Day{}, 0, solver.Answer{}, fmt.Errorf("invalid part %q: %w", args[2], err)
+	}
+
+	args, cleanup, err := resolveInput(day, args[3:])
+	if err != nil {
+		return solver.Day{}, 0, solver.Answer{}, err
+	}
+	defer cleanup()
+
+	start := time.Now()
+	answer, err := day.Run(part, args)
+	if err != nil {
+		return solver.Day{}, 0, solver.Answer{}, err
+	}
+	elapsed := time.Since(start)
+
+	fmt.Println(answer)
+	fmt.Fprintf(os.Stderr, "Solved %s part %d in %s\n", day, part, elapsed)
+
+	return day, part, answer, nil
+}
+
+func submit(args []string) error {
+	fset := flag.NewFlagSet("submit", flag.ContinueOnError)
+	history := fset.String("history", "", "file recording the submitted answers (default <input dir>/history.json)")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+
+	session := os.Getenv("AOC_SESSION")
+	if session == "" {
+		return errors.New("AOC_SESSION is not set, please provide the session cookie of the website")
+	}
+
+	if *history == "" {
+		dir, err := input.DefaultDir()
+		if err != nil {
+			return err
+		}
+		*history = filepath.Join(dir, "history.json")
+	}
+
+	h, err := client.LoadHistory(*history)
+	if err != nil {
+		return err
+	}
+
+	day, part, answer, err := solve(fset.Args())
+	if err != nil {
+		return err
+	}
+	if answer.Kind() == solver.KindNone {
+		return fmt.Errorf("%s part %d has no answer to submit", day, part)
+	}
+
+	now := time.Now()
+	if err = h.Check(day.Year, day.Day, part, answer.String(), now); err != nil {
+		return err
+	}
+
+	resp, err := client.New(session).Submit(context.Background(), day.Year, day.Day, part, answer.String())
+	if err != nil {
+		return err
+	}
+
+	attempt := client.Attempt{
+		Year:    day.Year,
+		Day:     day.Day,
+		Part:    part,
+		Answer:  answer.String(),
+		Verdict: resp.Verdict,
+		Time:    now,
+	}
+	if resp.Wait > 0 {
+		attempt.Until = now.Add(resp.Wait)
+	}
+	h.Record(attempt)
+
+	if err = h.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Answer %s is %s\n", answer, resp.Verdict)
+	if resp.Verdict == client.Unknown || resp.Verdict == client.WrongLevel {
+		fmt.Println(resp.Message)
+	}
+
+	return nil
+}
+
+func list(args []string) error {
+	year := 0
+	if len(args) > 0 {
+		var err error
+		if year, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("invalid year %q: %w", args[0], err)
+		}
+	}
+
+	for _, d := range solver.Days() {
+		if year == 0 || d.Year == year {
+			fmt.Println(d)
+		}
+	}
+
+	return nil
+}
+
+func check(args []string) error {
+	fset := flag.NewFlagSet("check", flag.ContinueOnError)
+	root := fset.String("root", ".", "directory containing the year directories")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	args = fset.Args()
+
+	year, day := 0, 0
+	var err error
+	if len(args) > 0 {
+		if year, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("invalid year %q: %w", args[0], err)
+		}
+	}
+	if len(args) > 1 {
+		if day, err = strconv.Atoi(args[1]); err != nil {
+			return fmt.Errorf("invalid day %q: %w", args[1], err)
+		}
+	}
+
+	answers := make(map[int]solver.Answers)
+	counts := make(map[solver.Status]int)
+
+	for _, d := range solver.Days() {
+		if (year != 0 && d.Year != year) || (day != 0 && d.Day != day) {
+			continue
+		}
+
+		if _, ok := answers[d.Year]; !ok {
+			a, err := solver.LoadAnswers(filepath.Join(*root, strconv.Itoa(d.Year), "answers.json"))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			answers[d.Year] = a
+		}
+
+		for _, result := range solver.Check(d, answers[d.Year][d.Day]) {
+			fmt.Println(result)
+			counts[result.Status]++
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d failed, %d errors, %d missing\n", counts[solver.Pass], counts[solver.Fail], counts[solver.Error], counts[solver.Missing])
+
+	if counts[solver.Fail] > 0 || counts[solver.Error] > 0 {
+		return errors.New("some answers are wrong")
+	}
+
+	return nil
+}
+
+func graph(args []string) error {
+	fset := flag.NewFlagSet("graph", flag.ContinueOnError)
+	out := fset.String("o", "", "write the graph to this file instead of stdout")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	args = fset.Args()
+
+	if len(args) < 2 {
+		return errors.New("missing arguments, please specify year and day")
+	}
+
+	day, err := lookup(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	if day.Graph == nil {
+		return fmt.Errorf("%s has no graph export", day)
+	}
+
+	args, cleanup, err := resolveInput(day, args[2:])
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	g, err := day.Graph(args)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return dot.Write(os.Stdout, g)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = dot.Write(f, g); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+func debugger(args []string) error {
+	fset := flag.NewFlagSet("debug", flag.ContinueOnError)
+	script := fset.String("script", "", "read the commands from this file instead of stdin")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	args = fset.Args()
+
+	if len(args) < 2 {
+		return errors.New("missing arguments, please specify year and day")
+	}
+
+	day, err := lookup(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	if day.Debug == nil {
+		return fmt.Errorf("%s has no debugger", day)
+	}
+
+	args, cleanup, err := resolveInput(day, args[2:])
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	m, err := day.Debug(args)
+	if err != nil {
+		return err
+	}
+
+	d := debug.New(m, os.Stdout)
+	if *script == "" {
+		d.Prompt = "(debug) "
+		return d.Run(os.Stdin)
+	}
+
+	f, err := os.Open(*script)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.Run(f)
+}
+
+// resolveInput replaces the first argument with the path of the input file. If the first argument
+// is missing or a flag of the task, the cached input is inserted in front. The returned function
+// removes temporary files.
+func resolveInput(day solver.Day, args []string) ([]string, func(), error) {
+	if day.Builtin {
+		return args, func() {}, nil
+	}
+
+	resolver, err := newResolver()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// without an input argument the flags of the task follow the part directly
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && args[0] != input.Stdin) {
+		args = append([]string{""}, args...)
+	}
+	arg := args[0]
+
+	if args[0], err = resolver.Resolve(context.Background(), day.Year, day.Day, arg); err != nil {
+		resolver.Close()
+		return nil, nil, err
+	}
+
+	return args, func() { resolver.Close() }, nil
+}
+
+// newResolver looks up inputs in the cache directory. If AOC_SESSION is set, missing inputs are
+// downloaded.
+func newResolver() (*input.Resolver, error) {
+	dir, err := input.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &input.Resolver{Dir: dir}
+	if session := os.Getenv("AOC_SESSION"); session != "" {
+		r.Fetcher = client.New(session)
+	}
+
+	return r, nil
+}
+
+func lookup(yearArg, dayArg string) (solver.Day, error) {
+	year, err := strconv.Atoi(yearArg)
+	if err != nil {
+		return solver.Day{}, fmt.Errorf("invalid year %q: %w", yearArg, err)
+	}
+
+	day, err := strconv.Atoi(dayArg)
+	if err != nil {
+		return solver.Day{}, fmt.Errorf("invalid day %q: %w", dayArg, err)
+	}
+
+	d, ok := solver.Lookup(year, day)
+	if !ok {
+		return solver.Day{}, fmt.Errorf("no solution for %d day %02d", year, day)
+	}
+
+	return d, nil
+}
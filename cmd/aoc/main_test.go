@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/noxer/aoc/input"
+	"github.com/noxer/aoc/solver"
+)
+
+func TestResolveInput(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AOC_INPUT_DIR", dir)
+	t.Setenv("AOC_SESSION", "")
+
+	cached := (&input.Resolver{Dir: dir}).Path(2024, 17)
+	if err := os.MkdirAll(filepath.Dir(cached), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cached, []byte("Register A: 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	day := solver.Day{Year: 2024, Day: 17}
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"no arguments", nil, []string{cached}},
+		{"empty input", []string{""}, []string{cached}},
+		{"only flags", []string{"-trace"}, []string{cached, "-trace"}},
+		{"flags and values", []string{"-n", "5"}, []string{cached, "-n", "5"}},
+		{"input file", []string{"main_test.go", "-trace"}, []string{"main_test.go", "-trace"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, done, err := resolveInput(day, tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer done()
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("resolveInput(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveInputMissing(t *testing.T) {
+	t.Setenv("AOC_INPUT_DIR", t.TempDir())
+	t.Setenv("AOC_SESSION", "")
+
+	if _, _, err := resolveInput(solver.Day{Year: 2024, Day: 17}, []string{"missing.txt"}); err == nil {
+		t.Error("resolveInput accepted a missing input file")
+	}
+}
@@ -0,0 +1,134 @@
+// Package input locates the puzzle input for the runner. Inputs are read from a cache directory,
+// from stdin or downloaded with a Fetcher.
+package input
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Stdin is the argument which makes the resolver read the input from stdin.
+const Stdin = "-"
+
+// ErrNotFound is returned if the input isn't cached and can't be downloaded.
+var ErrNotFound = errors.New("input not found")
+
+// Fetcher downloads the puzzle input for a day.
+type Fetcher interface {
+	Fetch(ctx context.Context, year, day int) ([]byte, error)
+}
+
+// DefaultDir returns $AOC_INPUT_DIR or the aoc directory inside the user's cache directory
+// (usually ~/.cache/aoc).
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("AOC_INPUT_DIR"); dir != "" {
+		return dir, nil
+	}
+
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cache, "aoc"), nil
+}
+
+// Resolver turns the input argument of the runner into a file path.
+type Resolver struct {
+	Dir     string
+	Fetcher Fetcher
+	Stdin   io.Reader
+
+	temp []string
+}
+
+// Path returns the location of the cached input, <dir>/<year>/<day>.txt.
+func (r *Resolver) Path(year, day int) string {
+	return filepath.Join(r.Dir, strconv.Itoa(year), strconv.Itoa(day)+".txt")
+}
+
+// Resolve returns the path of the input file. An empty argument looks up the cache and downloads
+// the input if it isn't cached yet, "-" reads the input from stdin and everything else is taken
+// as the path of the input file.
+func (r *Resolver) Resolve(ctx context.Context, year, day int, arg string) (string, error) {
+	switch arg {
+	case "":
+		return r.cached(ctx, year, day)
+	case Stdin:
+		return r.stdin()
+	}
+
+	if _, err := os.Stat(arg); err != nil {
+		return "", err
+	}
+
+	return arg, nil
+}
+
+func (r *Resolver) cached(ctx context.Context, year, day int) (string, error) {
+	name := r.Path(year, day)
+
+	_, err := os.Stat(name)
+	if err == nil {
+		return name, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	if r.Fetcher == nil {
+		return "", fmt.Errorf("%w: %s doesn't exist, set AOC_SESSION to download it or pass the file as argument", ErrNotFound, name)
+	}
+
+	data, err := r.Fetcher.Fetch(ctx, year, day)
+	if err != nil {
+		return "", fmt.Errorf("downloading input for %d day %02d: %w", year, day, err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", err
+	}
+	if err = os.WriteFile(name, data, 0o644); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// stdin copies stdin into a temporary file, the tasks expect a file path.
+func (r *Resolver) stdin() (string, error) {
+	in := r.Stdin
+	if in == nil {
+		in = os.Stdin
+	}
+
+	f, err := os.CreateTemp("", "aoc-input-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	r.temp = append(r.temp, f.Name())
+
+	if _, err = io.Copy(f, in); err != nil {
+		return "", err
+	}
+
+	return f.Name(), f.Close()
+}
+
+// Close removes the temporary files created while reading from stdin.
+func (r *Resolver) Close() error {
+	var errs []error
+	for _, name := range r.temp {
+		errs = append(errs, os.Remove(name))
+	}
+	r.temp = nil
+
+	return errors.Join(errs...)
+}
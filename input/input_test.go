@@ -0,0 +1,138 @@
+package input_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/noxer/aoc/client"
+	"github.com/noxer/aoc/input"
+)
+
+const session = "secret"
+
+// newServer starts a stand-in for the website which serves the input of 2024 day 1 to the user
+// with the session cookie above. It counts the requests in hits.
+func newServer(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		if r.URL.Path != "/2024/day/1/input" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if c, err := r.Cookie("session"); err != nil || c.Value != session {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte("3   4\n4   3\n"))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newResolver(t *testing.T, srv *httptest.Server, session string) *input.Resolver {
+	c := client.New(session)
+	c.BaseURL = srv.URL
+	c.HTTP = srv.Client()
+
+	r := &input.Resolver{
+		Dir:     t.TempDir(),
+		Fetcher: c,
+	}
+	t.Cleanup(func() { r.Close() })
+
+	return r
+}
+
+func TestResolveFetch(t *testing.T) {
+	var hits atomic.Int32
+	srv := newServer(t, &hits)
+	r := newResolver(t, srv, session)
+
+	// cache miss, the input is downloaded and stored
+	name, err := r.Resolve(context.Background(), 2024, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != r.Path(2024, 1) {
+		t.Errorf("Resolve() = %q, want %q", name, r.Path(2024, 1))
+	}
+	if data, err := os.ReadFile(name); err != nil || string(data) != "3   4\n4   3\n" {
+		t.Errorf("cached input = %q, %v", data, err)
+	}
+
+	// cache hit, the website isn't asked again
+	if _, err = r.Resolve(context.Background(), 2024, 1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("website was requested %d times, want 1", hits.Load())
+	}
+}
+
+func TestResolveBadSession(t *testing.T) {
+	var hits atomic.Int32
+	srv := newServer(t, &hits)
+	r := newResolver(t, srv, "expired")
+
+	_, err := r.Resolve(context.Background(), 2024, 1, "")
+	if err == nil || !strings.Contains(err.Error(), "400 Bad Request") {
+		t.Fatalf("Resolve() error = %v, want the status of the website", err)
+	}
+
+	// the error page must not end up in the cache
+	if _, err = os.Stat(r.Path(2024, 1)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("input was cached after a failed download: %v", err)
+	}
+}
+
+func TestResolveNoFetcher(t *testing.T) {
+	r := &input.Resolver{Dir: t.TempDir()}
+
+	if _, err := r.Resolve(context.Background(), 2024, 1, ""); !errors.Is(err, input.ErrNotFound) {
+		t.Errorf("Resolve() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestResolveStdin(t *testing.T) {
+	r := &input.Resolver{
+		Dir:   t.TempDir(),
+		Stdin: strings.NewReader("1 2 3\n"),
+	}
+
+	name, err := r.Resolve(context.Background(), 2024, 1, input.Stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(name); err != nil || string(data) != "1 2 3\n" {
+		t.Errorf("input from stdin = %q, %v", data, err)
+	}
+
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(name); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("temporary file still exists after Close: %v", err)
+	}
+}
+
+func TestResolveFile(t *testing.T) {
+	r := &input.Resolver{Dir: t.TempDir()}
+
+	if name, err := r.Resolve(context.Background(), 2024, 1, "input_test.go"); err != nil || name != "input_test.go" {
+		t.Errorf("Resolve() = %q, %v", name, err)
+	}
+	if _, err := r.Resolve(context.Background(), 2024, 1, "missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Resolve() error = %v, want ErrNotExist", err)
+	}
+}
@@ -0,0 +1,270 @@
+package circuit
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Issue describes a deviation of a ripple-carry adder from the canonical full adder pattern and
+// the swap repairing it.
+type Issue struct {
+	Bit  int
+	Role string
+	// Want is the expected gate, Got describes what was found instead.
+	Want Gate
+	Got  string
+	Swap [2]string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("bit %2d: %s should be %q but %s, swapping %s and %s", i.Bit, i.Role, i.Want, i.Got, i.Swap[0], i.Swap[1])
+}
+
+// Diagnosis is the result of verifying an adder.
+type Diagnosis struct {
+	Width  int
+	Issues []Issue
+}
+
+// Swapped returns the sorted names of all wires that had to be swapped.
+func (d Diagnosis) Swapped() []string {
+	var wires []string
+	for _, i := range d.Issues {
+		wires = append(wires, i.Swap[:]...)
+	}
+
+	slices.Sort(wires)
+	return slices.Compact(wires)
+}
+
+func (d Diagnosis) String() string {
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "%d bit adder, %d issues\n", d.Width, len(d.Issues))
+	for _, i := range d.Issues {
+		sb.WriteString(i.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// adder walks a ripple-carry adder bit by bit and fixes the deviations from the pattern
+//
+//	p = x XOR y    partial sum
+//	g = x AND y    generate
+//	z = p XOR c'   sum (c' is the carry of the previous bit)
+//	t = p AND c'   propagate
+//	c = g OR t     carry
+//
+// Bit 0 is a half adder (z = p, c = g) and the carry of the last bit is the extra output bit.
+type adder struct {
+	c       *Circuit
+	x, y, z []string
+	bit     int
+	issues  []Issue
+}
+
+// RepairAdder verifies that the circuit adds the buses x and y into the bus z (e.g. "x", "y" and
+// "z" for x00, x01, ...). Deviating gates are repaired by swapping output wires, the circuit is
+// changed in place. The diagnosis lists every swap with its reason. An error is returned if the
+// circuit can't be repaired with output swaps.
+func RepairAdder(c *Circuit, x, y, z string) (Diagnosis, error) {
+	a := &adder{
+		c: c,
+		x: c.Bus(x),
+		y: c.Bus(y),
+		z: c.Bus(z),
+	}
+
+	width := len(a.x)
+	if width == 0 || len(a.y) != width || len(a.z) != width+1 {
+		return Diagnosis{}, fmt.Errorf("buses don't form an adder: %d %s, %d %s and %d %s wires", len(a.x), x, len(a.y), y, len(a.z), z)
+	}
+
+	if err := a.repair(); err != nil {
+		return Diagnosis{Width: width, Issues: a.issues}, err
+	}
+
+	d := Diagnosis{Width: width, Issues: a.issues}
+	return d, verifyAdder(c, x, y, z, width)
+}
+
+func (a *adder) repair() error {
+	carry := ""
+
+	for a.bit = range a.x {
+		p, err := a.expect("partial sum", Xor, a.x[a.bit], a.y[a.bit])
+		if err != nil {
+			return err
+		}
+
+		if a.bit == 0 {
+			if err = a.output("sum", p, a.z[0]); err != nil {
+				return err
+			}
+
+			if carry, err = a.expect("carry", And, a.x[0], a.y[0]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		sum, err := a.expect("sum", Xor, p, carry)
+		if err != nil {
+			return err
+		}
+		if err = a.output("sum", sum, a.z[a.bit]); err != nil {
+			return err
+		}
+
+		// the previous steps may have swapped p or the carry, look them up again
+		if p, err = a.expect("partial sum", Xor, a.x[a.bit], a.y[a.bit]); err != nil {
+			return err
+		}
+		if carry, err = a.inputOther(a.z[a.bit], p); err != nil {
+			return err
+		}
+
+		g, err := a.expect("generate", And, a.x[a.bit], a.y[a.bit])
+		if err != nil {
+			return err
+		}
+		t, err := a.expect("propagate", And, p, carry)
+		if err != nil {
+			return err
+		}
+		if carry, err = a.expect("carry", Or, g, t); err != nil {
+			return err
+		}
+	}
+
+	a.bit = len(a.x)
+	return a.output("carry", carry, a.z[len(a.x)])
+}
+
+// find returns the gate with the operation and both inputs.
+func (a *adder) find(op Op, in1, in2 string) (Gate, bool) {
+	for _, g := range a.c.Gates() {
+		if g.Op == op && (g.A.Wire == in1 && g.B.Wire == in2 || g.A.Wire == in2 && g.B.Wire == in1) {
+			return g, true
+		}
+	}
+	return Gate{}, false
+}
+
+// expect returns the output wire of the gate combining in1 and in2 with op. If there is no such
+// gate but one combining one of the inputs with a different wire, the other input is considered
+// swapped.
+func (a *adder) expect(role string, op Op, in1, in2 string) (string, error) {
+	if g, ok := a.find(op, in1, in2); ok {
+		return g.Out, nil
+	}
+
+	want := Gate{Op: op, A: Operand{Wire: in1}, B: Operand{Wire: in2}}
+
+	for _, g := range a.c.Gates() {
+		if g.Op != op {
+			continue
+		}
+
+		for _, pair := range [][2]string{{in1, in2}, {in2, in1}} {
+			found, missing := pair[0], pair[1]
+
+			var other string
+			switch found {
+			case g.A.Wire:
+				other = g.B.Wire
+			case g.B.Wire:
+				other = g.A.Wire
+			default:
+				continue
+			}
+
+			got := fmt.Sprintf("found %q", g)
+			if err := a.swap(role, want, got, missing, other); err != nil {
+				return "", err
+			}
+
+			// the signal of the missing input now arrives on the other wire
+			if g, ok := a.find(op, found, other); ok {
+				return g.Out, nil
+			}
+			return "", fmt.Errorf("bit %d: no gate matches %s %q after swapping", a.bit, role, want)
+		}
+	}
+
+	return "", fmt.Errorf("bit %d: no gate matches %s %q", a.bit, role, want)
+}
+
+// output makes sure the signal is available on the given output wire.
+func (a *adder) output(role, wire, out string) error {
+	if wire == out {
+		return nil
+	}
+
+	g, _ := a.c.Gate(wire)
+	want := g
+	want.Out = out
+	got := fmt.Sprintf("it drives %s", wire)
+
+	return a.swap(role, want, got, wire, out)
+}
+
+// inputOther returns the input of the gate driving wire which isn't in.
+func (a *adder) inputOther(wire, in string) (string, error) {
+	g, ok := a.c.Gate(wire)
+	if !ok {
+		return "", fmt.Errorf("bit %d: wire %s has no driver", a.bit, wire)
+	}
+
+	if g.A.Wire == in {
+		return g.B.Wire, nil
+	}
+	return g.A.Wire, nil
+}
+
+func (a *adder) swap(role string, want Gate, got, w1, w2 string) error {
+	if len(a.issues) > 4*len(a.x) {
+		return fmt.Errorf("bit %d: too many swaps, giving up", a.bit)
+	}
+
+	if err := a.c.Swap(w1, w2); err != nil {
+		return fmt.Errorf("bit %d: %w", a.bit, err)
+	}
+
+	a.issues = append(a.issues, Issue{
+		Bit:  a.bit,
+		Role: role,
+		Want: want,
+		Got:  got,
+		Swap: [2]string{w1, w2},
+	})
+
+	return nil
+}
+
+// verifyAdder adds a few random numbers and checks the results.
+func verifyAdder(c *Circuit, x, y, z string, width int) error {
+	r := rand.New(rand.NewPCG(uint64(width), 24))
+	mask := ^uint64(0) >> (64 - width)
+
+	tests := [][2]uint64{{0, 0}, {mask, 1}, {mask, mask}}
+	for range 64 {
+		tests = append(tests, [2]uint64{r.Uint64() & mask, r.Uint64() & mask})
+	}
+
+	for _, t := range tests {
+		c.SetWord(x, t[0])
+		c.SetWord(y, t[1])
+		if err := c.Eval(); err != nil {
+			return err
+		}
+
+		if got := c.Word(z); got != t[0]+t[1] {
+			return fmt.Errorf("adder is still broken: %d + %d = %d", t[0], t[1], got)
+		}
+	}
+
+	return nil
+}
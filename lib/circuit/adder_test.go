@@ -0,0 +1,143 @@
+package circuit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// rippleCarry builds the netlist of a width bit ripple-carry adder in the pattern RepairAdder
+// expects. The internal wires of bit i are named p, g, t and c followed by i.
+func rippleCarry(width int) string {
+	sb := strings.Builder{}
+	for i := range width {
+		fmt.Fprintf(&sb, "x%02d: 0\ny%02d: 0\n", i, i)
+	}
+
+	carry := func(i int) string {
+		if i == width-1 {
+			return fmt.Sprintf("z%02d", width)
+		}
+		return fmt.Sprintf("c%02d", i)
+	}
+
+	fmt.Fprintf(&sb, "x00 XOR y00 -> z00\nx00 AND y00 -> %s\n", carry(0))
+	for i := 1; i < width; i++ {
+		fmt.Fprintf(&sb, "x%02d XOR y%02d -> p%02d\n", i, i, i)
+		fmt.Fprintf(&sb, "x%02d AND y%02d -> g%02d\n", i, i, i)
+		fmt.Fprintf(&sb, "p%02d XOR %s -> z%02d\n", i, carry(i-1), i)
+		fmt.Fprintf(&sb, "p%02d AND %s -> t%02d\n", i, carry(i-1), i)
+		fmt.Fprintf(&sb, "g%02d OR t%02d -> %s\n", i, i, carry(i))
+	}
+
+	return sb.String()
+}
+
+func parseAdder(t *testing.T, width int) *Circuit {
+	t.Helper()
+
+	c, err := Parse(strings.NewReader(rippleCarry(width)), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRepairAdder(t *testing.T) {
+	// issues are given as "bit role swap0 swap1"
+	tests := []struct {
+		name   string
+		swaps  [][2]string
+		issues []string
+	}{
+		{"intact", nil, nil},
+		{"sum output", [][2]string{{"z05", "c05"}}, []string{"5 sum c05 z05"}},
+		{"sum input", [][2]string{{"p07", "g07"}}, []string{"7 sum g07 p07"}},
+		{"sum and propagate", [][2]string{{"z10", "t10"}}, []string{"10 sum t10 z10"}},
+		{"carry input", [][2]string{{"t03", "c03"}}, []string{"3 carry c03 t03"}},
+		{"two sums", [][2]string{{"z12", "z13"}}, []string{"12 sum z13 z12"}},
+		{"carry out", [][2]string{{"z16", "c14"}}, []string{"15 sum z16 c14"}},
+		{
+			"four pairs",
+			[][2]string{{"z05", "c05"}, {"p07", "g07"}, {"z10", "t10"}, {"t03", "c03"}},
+			[]string{"3 carry c03 t03", "5 sum c05 z05", "7 sum g07 p07", "10 sum t10 z10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := parseAdder(t, 16)
+
+			var want []string
+			for _, s := range tt.swaps {
+				if err := c.Swap(s[0], s[1]); err != nil {
+					t.Fatal(err)
+				}
+				want = append(want, s[:]...)
+			}
+			slices.Sort(want)
+
+			d, err := RepairAdder(c, "x", "y", "z")
+			if err != nil {
+				t.Fatalf("RepairAdder() error = %v\n%s", err, d)
+			}
+
+			if got := d.Swapped(); !slices.Equal(got, want) {
+				t.Errorf("Swapped() = %v, want %v", got, want)
+			}
+
+			var issues []string
+			for _, i := range d.Issues {
+				issues = append(issues, fmt.Sprintf("%d %s %s %s", i.Bit, i.Role, i.Swap[0], i.Swap[1]))
+			}
+			if !slices.Equal(issues, tt.issues) || d.Width != 16 {
+				t.Errorf("Diagnosis = %s, want issues %q", d, tt.issues)
+			}
+
+			// the circuit is repaired in place
+			c.SetWord("x", 40000)
+			c.SetWord("y", 30000)
+			if err = c.Eval(); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Word("z"); got != 70000 {
+				t.Errorf("repaired adder: 40000 + 30000 = %d", got)
+			}
+		})
+	}
+}
+
+func TestRepairAdderDiagnosis(t *testing.T) {
+	c := parseAdder(t, 8)
+	if err := c.Swap("z03", "c03"); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := RepairAdder(c, "x", "y", "z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "8 bit adder, 1 issues\n" +
+		`bit  3: sum should be "p03 XOR c02 -> z03" but it drives c03, swapping c03 and z03` + "\n"
+	if got := d.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRepairAdderErrors(t *testing.T) {
+	// the buses are too short for an adder
+	c := mustParse(t, "x00: 0\ny00: 0\nx00 XOR y00 -> z00\n", 1)
+	if _, err := RepairAdder(c, "x", "y", "z"); err == nil || !strings.Contains(err.Error(), "don't form an adder") {
+		t.Errorf("RepairAdder() error = %v, want the bus sizes", err)
+	}
+
+	// no swap brings back a missing gate
+	netlist := strings.Replace(rippleCarry(4), "x02 AND y02 -> g02\n", "", 1)
+	netlist = strings.Replace(netlist, "g02 OR", "x02 OR", 1)
+	c = mustParse(t, netlist, 1)
+	if _, err := RepairAdder(c, "x", "y", "z"); err == nil || !strings.Contains(err.Error(), "bit 2: no gate matches generate") {
+		t.Errorf("RepairAdder() error = %v, want a failure at bit 2", err)
+	}
+}
@@ -0,0 +1,398 @@
+// Package circuit simulates netlists of logic gates. Wires carry words of a configurable width,
+// gates are evaluated once in topological order.
+package circuit
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/noxer/aoc/lib/dot"
+)
+
+// Op is the operation performed by a gate.
+type Op int
+
+const (
+	Buf Op = iota // copies A to the output
+	Not
+	And
+	Or
+	Xor
+	LShift
+	RShift
+)
+
+var opNames = [...]string{
+	Buf:    "",
+	Not:    "NOT",
+	And:    "AND",
+	Or:     "OR",
+	Xor:    "XOR",
+	LShift: "LSHIFT",
+	RShift: "RSHIFT",
+}
+
+func (o Op) String() string {
+	if o < 0 || int(o) >= len(opNames) {
+		return "Op(" + strconv.Itoa(int(o)) + ")"
+	}
+	return opNames[o]
+}
+
+// Operand is the input of a gate, either a wire or a constant if Wire is empty.
+type Operand struct {
+	Wire  string
+	Const uint64
+}
+
+func (o Operand) String() string {
+	if o.Wire == "" {
+		return strconv.FormatUint(o.Const, 10)
+	}
+	return o.Wire
+}
+
+func (o Operand) wires() []string {
+	if o.Wire == "" {
+		return nil
+	}
+	return []string{o.Wire}
+}
+
+// Gate drives the wire Out. Buf and Not only use A.
+type Gate struct {
+	Op   Op
+	A, B Operand
+	Out  string
+}
+
+// Inputs returns the names of the wires the gate reads.
+func (g Gate) Inputs() []string {
+	if g.Op == Buf || g.Op == Not {
+		return g.A.wires()
+	}
+	return append(g.A.wires(), g.B.wires()...)
+}
+
+// String formats the gate like the input files. The output is left out if it is empty.
+func (g Gate) String() string {
+	var expr string
+	switch g.Op {
+	case Buf:
+		expr = g.A.String()
+	case Not:
+		expr = "NOT " + g.A.String()
+	default:
+		expr = fmt.Sprintf("%s %s %s", g.A, g.Op, g.B)
+	}
+
+	if g.Out == "" {
+		return expr
+	}
+	return expr + " -> " + g.Out
+}
+
+// Circuit is a netlist of gates together with the current values of all wires.
+type Circuit struct {
+	mask   uint64
+	gates  map[string]Gate
+	wires  []string // output wires in the order they were added
+	fanout map[string][]string
+
+	order  []string // topological order, nil if it needs to be recomputed
+	values map[string]uint64
+	dirty  map[string]bool
+}
+
+// New creates an empty circuit whose wires are width bits wide (1 to 64).
+func New(width int) *Circuit {
+	if width < 1 || width > 64 {
+		panic(fmt.Sprintf("circuit: invalid width %d", width))
+	}
+
+	return &Circuit{
+		mask:   ^uint64(0) >> (64 - width),
+		gates:  make(map[string]Gate),
+		values: make(map[string]uint64),
+		dirty:  make(map[string]bool),
+	}
+}
+
+// Add inserts a gate. It returns an error if the output wire is driven already.
+func (c *Circuit) Add(g Gate) error {
+	if _, ok := c.gates[g.Out]; ok {
+		return fmt.Errorf("wire %s is driven twice", g.Out)
+	}
+
+	c.gates[g.Out] = g
+	c.wires = append(c.wires, g.Out)
+	c.invalidate()
+
+	return nil
+}
+
+// invalidate forces the next evaluation to sort the gates and recompute every wire.
+func (c *Circuit) invalidate() {
+	c.order = nil
+	c.fanout = nil
+}
+
+// Gate returns the gate driving the wire.
+func (c *Circuit) Gate(wire string) (Gate, bool) {
+	g, ok := c.gates[wire]
+	return g, ok
+}
+
+// Gates returns all gates in the order they were added.
+func (c *Circuit) Gates() []Gate {
+	gates := make([]Gate, len(c.wires))
+	for i, w := range c.wires {
+		gates[i] = c.gates[w]
+	}
+	return gates
+}
+
+// Readers returns the gates which have the wire as input.
+func (c *Circuit) Readers(wire string) []Gate {
+	var gates []Gate
+	for _, w := range c.wires {
+		if slices.Contains(c.gates[w].Inputs(), wire) {
+			gates = append(gates, c.gates[w])
+		}
+	}
+	return gates
+}
+
+// Set overrides the wire with a constant value. Only the wires depending on it are recomputed by
+// the next call to Eval.
+func (c *Circuit) Set(wire string, value uint64) {
+	_, ok := c.gates[wire]
+	c.gates[wire] = Gate{Op: Buf, A: Operand{Const: value & c.mask}, Out: wire}
+
+	if !ok {
+		c.wires = append(c.wires, wire)
+		c.invalidate()
+		return
+	}
+
+	// the topological order stays valid, the wire just lost its inputs
+	if c.order != nil {
+		c.markDirty(wire)
+	}
+}
+
+// Swap exchanges the gates driving the two wires.
+func (c *Circuit) Swap(a, b string) error {
+	ga, ok := c.gates[a]
+	if !ok {
+		return fmt.Errorf("wire %s has no driver", a)
+	}
+	gb, ok := c.gates[b]
+	if !ok {
+		return fmt.Errorf("wire %s has no driver", b)
+	}
+
+	ga.Out, gb.Out = b, a
+	c.gates[a], c.gates[b] = gb, ga
+	c.invalidate()
+
+	return nil
+}
+
+func (c *Circuit) markDirty(wire string) {
+	if c.dirty[wire] {
+		return
+	}
+	c.dirty[wire] = true
+
+	for _, r := range c.fanout[wire] {
+		c.markDirty(r)
+	}
+}
+
+// sort orders the wires so every gate comes after the gates driving its inputs.
+func (c *Circuit) sort() error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make(map[string]int, len(c.gates))
+	order := make([]string, 0, len(c.gates))
+	var path []string
+
+	var visit func(wire string) error
+	visit = func(wire string) error {
+		switch state[wire] {
+		case done:
+			return nil
+		case visiting:
+			start := slices.Index(path, wire)
+			cycle := append(path[start:], wire)
+			return fmt.Errorf("cycle detected: %s", strings.Join(cycle, " <- "))
+		}
+
+		g, ok := c.gates[wire]
+		if !ok {
+			if len(path) > 0 {
+				return fmt.Errorf("wire %s read by %s has no driver", wire, path[len(path)-1])
+			}
+			return fmt.Errorf("wire %s has no driver", wire)
+		}
+
+		state[wire] = visiting
+		path = append(path, wire)
+
+		for _, in := range g.Inputs() {
+			if err := visit(in); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[wire] = done
+		order = append(order, wire)
+
+		return nil
+	}
+
+	for _, w := range c.wires {
+		if err := visit(w); err != nil {
+			return err
+		}
+	}
+
+	c.order = order
+	c.fanout = make(map[string][]string)
+	for _, w := range order {
+		for _, in := range c.gates[w].Inputs() {
+			c.fanout[in] = append(c.fanout[in], w)
+		}
+	}
+
+	return nil
+}
+
+// Eval computes the values of all wires whose inputs changed since the last evaluation. It
+// returns an error if the circuit contains a cycle or reads a wire without driver.
+func (c *Circuit) Eval() error {
+	if c.order == nil {
+		if err := c.sort(); err != nil {
+			return err
+		}
+
+		clear(c.values)
+		for _, w := range c.order {
+			c.values[w] = c.eval(c.gates[w])
+		}
+		clear(c.dirty)
+
+		return nil
+	}
+
+	if len(c.dirty) == 0 {
+		return nil
+	}
+
+	for _, w := range c.order {
+		if c.dirty[w] {
+			c.values[w] = c.eval(c.gates[w])
+		}
+	}
+	clear(c.dirty)
+
+	return nil
+}
+
+func (c *Circuit) operand(o Operand) uint64 {
+	if o.Wire == "" {
+		return o.Const
+	}
+	return c.values[o.Wire]
+}
+
+func (c *Circuit) eval(g Gate) uint64 {
+	// constants can be wider than the wires, only the shift amount is used as is
+	a, b := c.operand(g.A)&c.mask, c.operand(g.B)
+
+	switch g.Op {
+	case Buf:
+		return a
+	case Not:
+		return ^a & c.mask
+	case And:
+		return a & b
+	case Or:
+		return (a | b) & c.mask
+	case Xor:
+		return (a ^ b) & c.mask
+	case LShift:
+		return (a << b) & c.mask
+	case RShift:
+		return a >> b
+	}
+
+	panic(fmt.Sprintf("circuit: unknown operation %d", g.Op))
+}
+
+// Value returns the value of the wire computed by the last evaluation.
+func (c *Circuit) Value(wire string) (uint64, bool) {
+	v, ok := c.values[wire]
+	return v, ok
+}
+
+// Bus returns the wires named prefix00, prefix01, ... in order. The numbering must be contiguous.
+func (c *Circuit) Bus(prefix string) []string {
+	var bus []string
+	for i := 0; ; i++ {
+		w := fmt.Sprintf("%s%02d", prefix, i)
+		if _, ok := c.gates[w]; !ok {
+			return bus
+		}
+		bus = append(bus, w)
+	}
+}
+
+// SetWord distributes the bits of v over the 1 bit wires of the bus, least significant bit first.
+func (c *Circuit) SetWord(prefix string, v uint64) {
+	for i, w := range c.Bus(prefix) {
+		c.Set(w, (v>>i)&1)
+	}
+}
+
+// Word assembles the 1 bit wires of the bus into a number, least significant bit first.
+func (c *Circuit) Word(prefix string) uint64 {
+	v := uint64(0)
+	for i, w := range c.Bus(prefix) {
+		v |= (c.values[w] & 1) << i
+	}
+	return v
+}
+
+// Graph exports the netlist, every wire is a node labelled with the operation driving it.
+func (c *Circuit) Graph() *dot.Graph {
+	g := dot.New("circuit", true)
+
+	for _, w := range c.wires {
+		gate := c.gates[w]
+
+		label := w + "\n" + gate.Op.String()
+		if gate.Op == Buf && gate.A.Wire == "" {
+			label = w + " = " + gate.A.String()
+		}
+
+		n := g.Node(w, label)
+		if len(gate.Inputs()) == 0 {
+			n.Shape = "box"
+		}
+
+		for _, in := range gate.Inputs() {
+			g.Edge(in, w, "")
+		}
+	}
+
+	return g
+}
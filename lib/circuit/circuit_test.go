@@ -0,0 +1,163 @@
+package circuit
+
+import (
+	"strings"
+	"testing"
+)
+
+// example is the circuit of 2015 day 7 with the gates in reverse order.
+const example = `NOT y -> i
+NOT x -> h
+y RSHIFT 2 -> g
+x LSHIFT 2 -> f
+x OR y -> e
+x AND y -> d
+456 -> y
+123 -> x
+`
+
+func mustParse(t *testing.T, netlist string, width int) *Circuit {
+	t.Helper()
+
+	c, err := Parse(strings.NewReader(netlist), width)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func checkValues(t *testing.T, c *Circuit, want map[string]uint64) {
+	t.Helper()
+
+	for w, v := range want {
+		if got, ok := c.Value(w); !ok || got != v {
+			t.Errorf("Value(%s) = %d, %t, want %d", w, got, ok, v)
+		}
+	}
+}
+
+func TestEvalOrder(t *testing.T) {
+	c := mustParse(t, example, 16)
+	if err := c.Eval(); err != nil {
+		t.Fatal(err)
+	}
+
+	checkValues(t, c, map[string]uint64{
+		"d": 72, "e": 507, "f": 492, "g": 114, "h": 65412, "i": 65079, "x": 123, "y": 456,
+	})
+}
+
+func TestEvalCycle(t *testing.T) {
+	c := mustParse(t, "a AND c -> b\nb OR 1 -> c\nd -> a\n0 -> d\n", 16)
+
+	err := c.Eval()
+	if err == nil || !strings.Contains(err.Error(), "cycle detected") {
+		t.Fatalf("Eval() error = %v, want a cycle", err)
+	}
+	if !strings.Contains(err.Error(), "b <- c <- b") && !strings.Contains(err.Error(), "c <- b <- c") {
+		t.Errorf("Eval() error = %v, want the wires of the cycle", err)
+	}
+
+	c = mustParse(t, "a AND b -> c\n1 -> a\n", 16)
+	if err = c.Eval(); err == nil || !strings.Contains(err.Error(), "wire b read by c has no driver") {
+		t.Errorf("Eval() error = %v, want the missing driver", err)
+	}
+}
+
+func TestEvalIncremental(t *testing.T) {
+	c := mustParse(t, example, 16)
+	if err := c.Eval(); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the readers of x change
+	c.Set("x", 1)
+	if v, _ := c.Value("d"); v != 72 {
+		t.Errorf("Value(d) = %d before Eval, want the old value 72", v)
+	}
+	if err := c.Eval(); err != nil {
+		t.Fatal(err)
+	}
+	checkValues(t, c, map[string]uint64{
+		"d": 0, "e": 457, "f": 4, "g": 114, "h": 65534, "i": 65079,
+	})
+
+	// a wire driven by a gate becomes a constant
+	c.Set("e", 7)
+	if err := c.Eval(); err != nil {
+		t.Fatal(err)
+	}
+	checkValues(t, c, map[string]uint64{"e": 7, "d": 0})
+
+	// a new wire forces a full evaluation
+	c.Set("z", 3)
+	if err := c.Add(Gate{Op: And, A: Operand{Wire: "z"}, B: Operand{Wire: "e"}, Out: "w"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Eval(); err != nil {
+		t.Fatal(err)
+	}
+	checkValues(t, c, map[string]uint64{"w": 3, "x": 1})
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		gate  string
+		width int
+		want  uint64
+	}{
+		{"not", "NOT 5 -> a", 4, 10},
+		{"buf", "300 -> a", 8, 44},
+		{"and", "65535 AND 65535 -> a", 8, 255},
+		{"or", "256 OR 1 -> a", 8, 1},
+		{"xor", "4096 XOR 0 -> a", 12, 0},
+		{"lshift", "3 LSHIFT 7 -> a", 8, 128},
+		{"rshift", "65280 RSHIFT 4 -> a", 12, 240},
+		{"wide shift", "1 LSHIFT 70 -> a", 64, 0},
+		{"one bit", "3 XOR 1 -> a", 1, 0},
+		{"64 bits", "NOT 0 -> a", 64, ^uint64(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := mustParse(t, tt.gate, tt.width)
+			if err := c.Eval(); err != nil {
+				t.Fatal(err)
+			}
+			if v, _ := c.Value("a"); v != tt.want {
+				t.Errorf("%s with width %d = %d, want %d", tt.gate, tt.width, v, tt.want)
+			}
+		})
+	}
+}
+
+func TestWord(t *testing.T) {
+	c := mustParse(t, "x00: 1\nx01: 0\nx02: 1\nx00 AND x02 -> z00\nx01 OR x02 -> z01\n", 1)
+
+	if got := c.Bus("x"); len(got) != 3 {
+		t.Errorf("Bus(x) = %v", got)
+	}
+
+	c.SetWord("x", 0b010)
+	if err := c.Eval(); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Word("z"); got != 0b10 {
+		t.Errorf("Word(z) = %b, want 10", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, netlist := range []string{
+		"x NAND y -> z",
+		"x AND y",
+		"x: y",
+		"1 -> a\n2 -> a",
+		"99999999999999999999 -> a",
+	} {
+		if _, err := Parse(strings.NewReader(netlist), 16); err == nil {
+			t.Errorf("Parse(%q) succeeded", netlist)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package circuit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var opsByName = map[string]Op{
+	"AND":    And,
+	"OR":     Or,
+	"XOR":    Xor,
+	"LSHIFT": LShift,
+	"RSHIFT": RShift,
+}
+
+// Parse reads a netlist. It understands gates like "x AND y -> z", "NOT x -> y", "123 -> x" as
+// well as input assignments like "x00: 1". Empty lines are ignored.
+func Parse(r io.Reader, width int) (*Circuit, error) {
+	c := New(width)
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+
+		g, err := parseGate(text)
+		if err == nil {
+			err = c.Add(g)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	return c, s.Err()
+}
+
+// ParseFile reads a netlist from a file.
+func ParseFile(name string, width int) (*Circuit, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f, width)
+}
+
+func parseGate(line string) (Gate, error) {
+	if wire, value, ok := strings.Cut(line, ": "); ok {
+		v, err := parseOperand(value)
+		if err != nil || v.Wire != "" {
+			return Gate{}, fmt.Errorf("invalid value %q for wire %s", value, wire)
+		}
+		return Gate{Op: Buf, A: v, Out: wire}, nil
+	}
+
+	expr, out, ok := strings.Cut(line, " -> ")
+	if !ok || out == "" {
+		return Gate{}, fmt.Errorf("invalid gate %q", line)
+	}
+
+	fields := strings.Fields(expr)
+	switch {
+	case len(fields) == 1:
+		a, err := parseOperand(fields[0])
+		return Gate{Op: Buf, A: a, Out: out}, err
+
+	case len(fields) == 2 && fields[0] == "NOT":
+		a, err := parseOperand(fields[1])
+		return Gate{Op: Not, A: a, Out: out}, err
+
+	case len(fields) == 3:
+		op, ok := opsByName[fields[1]]
+		if !ok {
+			return Gate{}, fmt.Errorf("unknown operation %q", fields[1])
+		}
+
+		a, err := parseOperand(fields[0])
+		if err != nil {
+			return Gate{}, err
+		}
+		b, err := parseOperand(fields[2])
+		return Gate{Op: op, A: a, B: b, Out: out}, err
+	}
+
+	return Gate{}, fmt.Errorf("invalid gate %q", line)
+}
+
+func parseOperand(s string) (Operand, error) {
+	if s == "" {
+		return Operand{}, fmt.Errorf("empty operand")
+	}
+
+	if s[0] >= '0' && s[0] <= '9' {
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return Operand{}, err
+		}
+		return Operand{Const: v}, nil
+	}
+
+	return Operand{Wire: s}, nil
+}
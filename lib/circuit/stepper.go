@@ -0,0 +1,88 @@
+package circuit
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/noxer/aoc/lib/debug"
+)
+
+// Stepper evaluates a circuit one gate at a time in topological order, so it can be inspected in
+// the debugger. The position is the index of the next gate in that order.
+type Stepper struct {
+	c   *Circuit
+	pos int
+}
+
+// Stepper prepares a step by step evaluation of the circuit. All wire values are cleared.
+func (c *Circuit) Stepper() (*Stepper, error) {
+	s := &Stepper{c: c}
+	if err := s.Restart(nil); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Step evaluates the next gate.
+func (s *Stepper) Step() bool {
+	if s.pos >= len(s.c.order) {
+		return false
+	}
+
+	w := s.c.order[s.pos]
+	s.c.values[w] = s.c.eval(s.c.gates[w])
+	s.pos++
+
+	return true
+}
+
+// Position returns the index of the next gate.
+func (s *Stepper) Position() int {
+	return s.pos
+}
+
+// Next returns the next gate in the syntax of the input files.
+func (s *Stepper) Next() string {
+	if s.pos >= len(s.c.order) {
+		return "halted"
+	}
+	return s.c.gates[s.c.order[s.pos]].String()
+}
+
+// State returns the wires in the order they were added, wires which haven't been evaluated yet
+// have the value "?".
+func (s *Stepper) State() []debug.Register {
+	regs := make([]debug.Register, len(s.c.wires))
+	for i, w := range s.c.wires {
+		regs[i] = debug.Register{Name: w, Value: "?"}
+		if v, ok := s.c.values[w]; ok {
+			regs[i].Value = strconv.FormatUint(v, 10)
+		}
+	}
+	return regs
+}
+
+// Restart overrides the given wires with constants and clears all values.
+func (s *Stepper) Restart(values map[string]int) error {
+	for w, v := range values {
+		if _, ok := s.c.gates[w]; !ok {
+			return fmt.Errorf("unknown wire %s", w)
+		}
+		if v < 0 {
+			return fmt.Errorf("negative value for wire %s", w)
+		}
+		s.c.Set(w, uint64(v))
+	}
+
+	if s.c.order == nil {
+		if err := s.c.sort(); err != nil {
+			return err
+		}
+	}
+
+	clear(s.c.values)
+	clear(s.c.dirty)
+	s.pos = 0
+
+	return nil
+}
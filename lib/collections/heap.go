@@ -1,13 +1,19 @@
-package utils
+package collections
 
 import (
 	"cmp"
 	"container/heap"
 )
 
+// scored is an element together with its score, which is computed once when it is pushed.
+type scored[T cmp.Ordered, S any] struct {
+	score T
+	elem  S
+}
+
 type heapSlice[T cmp.Ordered, S any] struct {
 	score func(S) T
-	data  []S
+	data  []scored[T, S]
 }
 
 func (h heapSlice[T, S]) Len() int {
@@ -15,7 +21,7 @@ func (h heapSlice[T, S]) Len() int {
 }
 
 func (h heapSlice[T, S]) Less(i, j int) bool {
-	return h.score(h.data[i]) < h.score(h.data[j])
+	return h.data[i].score < h.data[j].score
 }
 
 func (h heapSlice[T, S]) Swap(i, j int) {
@@ -23,7 +29,7 @@ func (h heapSlice[T, S]) Swap(i, j int) {
 }
 
 func (h *heapSlice[T, S]) Push(x any) {
-	h.data = append(h.data, x.(S))
+	h.data = append(h.data, x.(scored[T, S]))
 }
 
 func (h *heapSlice[T, S]) Pop() any {
@@ -43,7 +49,10 @@ func NewHeap[S any, T cmp.Ordered](score func(S) T, elems ...S) Heap[T, S] {
 
 	hs := &heapSlice[T, S]{
 		score: score,
-		data:  elems,
+		data:  make([]scored[T, S], len(elems)),
+	}
+	for i, elem := range elems {
+		hs.data[i] = scored[T, S]{score: score(elem), elem: elem}
 	}
 
 	h := Heap[T, S]{
@@ -55,11 +64,11 @@ func NewHeap[S any, T cmp.Ordered](score func(S) T, elems ...S) Heap[T, S] {
 }
 
 func (h Heap[T, S]) Push(elem S) {
-	heap.Push(h.data, elem)
+	heap.Push(h.data, scored[T, S]{score: h.data.score(elem), elem: elem})
 }
 
 func (h Heap[T, S]) Pop() S {
-	return heap.Pop(h.data).(S)
+	return heap.Pop(h.data).(scored[T, S]).elem
 }
 
 func (h Heap[T, S]) Len() int {
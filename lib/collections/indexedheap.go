@@ -0,0 +1,182 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Handle identifies an element pushed to an IndexedHeap. It is returned by Push and used to
+// update or remove the element later on.
+type Handle int
+
+type indexedItem[T any, P cmp.Ordered] struct {
+	value    T
+	priority P
+	handle   Handle
+}
+
+type indexedData[T any, P cmp.Ordered] struct {
+	max  bool
+	heap []indexedItem[T, P]
+	pos  []int // position in heap by handle, -1 once the element left the heap
+}
+
+func (d *indexedData[T, P]) less(i, j int) bool {
+	if d.max {
+		return d.heap[i].priority > d.heap[j].priority
+	}
+	return d.heap[i].priority < d.heap[j].priority
+}
+
+func (d *indexedData[T, P]) swap(i, j int) {
+	d.heap[i], d.heap[j] = d.heap[j], d.heap[i]
+	d.pos[d.heap[i].handle] = i
+	d.pos[d.heap[j].handle] = j
+}
+
+func (d *indexedData[T, P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !d.less(i, parent) {
+			return
+		}
+		d.swap(i, parent)
+		i = parent
+	}
+}
+
+func (d *indexedData[T, P]) down(i int) bool {
+	start := i
+	for {
+		child := 2*i + 1
+		if child >= len(d.heap) {
+			break
+		}
+		if right := child + 1; right < len(d.heap) && d.less(right, child) {
+			child = right
+		}
+		if !d.less(child, i) {
+			break
+		}
+		d.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// remove takes the element at position i out of the heap and returns it.
+func (d *indexedData[T, P]) remove(i int) indexedItem[T, P] {
+	last := len(d.heap) - 1
+	if i != last {
+		d.swap(i, last)
+	}
+
+	it := d.heap[last]
+	d.heap[last] = indexedItem[T, P]{}
+	d.heap = d.heap[:last]
+	d.pos[it.handle] = -1
+
+	if i != last && !d.down(i) {
+		d.up(i)
+	}
+	return it
+}
+
+// IndexedHeap is a priority queue which supports changing the priority of and removing elements
+// already in the queue. Priorities are stored with the elements and never recomputed. Handles are
+// handed out in push order starting at 0, so they can index a slice kept next to the heap.
+type IndexedHeap[T any, P cmp.Ordered] struct {
+	data *indexedData[T, P]
+}
+
+// NewIndexedHeap creates a heap which pops the element with the lowest priority first.
+func NewIndexedHeap[T any, P cmp.Ordered]() IndexedHeap[T, P] {
+	return IndexedHeap[T, P]{
+		data: &indexedData[T, P]{},
+	}
+}
+
+// NewMaxIndexedHeap creates a heap which pops the element with the highest priority first.
+func NewMaxIndexedHeap[T any, P cmp.Ordered]() IndexedHeap[T, P] {
+	return IndexedHeap[T, P]{
+		data: &indexedData[T, P]{max: true},
+	}
+}
+
+// Push adds an element with the given priority and returns its handle.
+func (h IndexedHeap[T, P]) Push(value T, priority P) Handle {
+	d := h.data
+
+	it := Handle(len(d.pos))
+	d.pos = append(d.pos, len(d.heap))
+	d.heap = append(d.heap, indexedItem[T, P]{value: value, priority: priority, handle: it})
+	d.up(len(d.heap) - 1)
+
+	return it
+}
+
+// Pop removes and returns the element with the best priority. It panics if the heap is empty.
+func (h IndexedHeap[T, P]) Pop() (T, P) {
+	if h.Len() == 0 {
+		panic("Pop called on empty heap")
+	}
+
+	it := h.data.remove(0)
+	return it.value, it.priority
+}
+
+// Peek returns the element with the best priority without removing it. It panics if the heap is
+// empty.
+func (h IndexedHeap[T, P]) Peek() (T, P) {
+	if h.Len() == 0 {
+		panic("Peek called on empty heap")
+	}
+
+	it := h.data.heap[0]
+	return it.value, it.priority
+}
+
+// Update changes the priority of an element still in the heap.
+func (h IndexedHeap[T, P]) Update(it Handle, priority P) {
+	if !h.Contains(it) {
+		panic("Update called with item not in heap")
+	}
+
+	d := h.data
+	pos := d.pos[it]
+	d.heap[pos].priority = priority
+	if !d.down(pos) {
+		d.up(pos)
+	}
+}
+
+// Remove deletes an element from the heap.
+func (h IndexedHeap[T, P]) Remove(it Handle) {
+	if !h.Contains(it) {
+		panic("Remove called with item not in heap")
+	}
+
+	h.data.remove(h.data.pos[it])
+}
+
+// Contains reports whether the element is still in the heap.
+func (h IndexedHeap[T, P]) Contains(it Handle) bool {
+	return it >= 0 && int(it) < len(h.data.pos) && h.data.pos[it] >= 0
+}
+
+// Len returns the number of elements in the heap.
+func (h IndexedHeap[T, P]) Len() int {
+	return len(h.data.heap)
+}
+
+// Drain pops the elements in priority order. Elements pushed or updated while iterating are part
+// of the iteration.
+func (h IndexedHeap[T, P]) Drain() iter.Seq2[T, P] {
+	return func(yield func(T, P) bool) {
+		for h.Len() > 0 {
+			if !yield(h.Pop()) {
+				return
+			}
+		}
+	}
+}
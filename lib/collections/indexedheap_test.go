@@ -0,0 +1,187 @@
+package collections
+
+import (
+	"cmp"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// drain pops all elements and returns them in the order they were popped.
+func drain[T any, P cmp.Ordered](h IndexedHeap[T, P]) []T {
+	var out []T
+	for v := range h.Drain() {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestIndexedHeapDrain(t *testing.T) {
+	h := NewIndexedHeap[string, int]()
+	for i, s := range []string{"d", "b", "e", "a", "c"} {
+		h.Push(s, []int{4, 2, 5, 1, 3}[i])
+	}
+
+	if v, p := h.Peek(); v != "a" || p != 1 || h.Len() != 5 {
+		t.Errorf("Peek() = %s, %d with %d elements", v, p, h.Len())
+	}
+	if got := drain(h); !slices.Equal(got, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Drain() = %v", got)
+	}
+	if h.Len() != 0 {
+		t.Errorf("Len() = %d after Drain", h.Len())
+	}
+}
+
+func TestIndexedHeapDrainPush(t *testing.T) {
+	h := NewIndexedHeap[int, int]()
+	h.Push(1, 1)
+
+	var got []int
+	for v, p := range h.Drain() {
+		got = append(got, v)
+		if v < 4 {
+			h.Push(v+1, p+1)
+		}
+	}
+
+	if !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Drain() = %v", got)
+	}
+}
+
+func TestMaxIndexedHeap(t *testing.T) {
+	h := NewMaxIndexedHeap[int, float64]()
+	for _, v := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+		h.Push(v, float64(v)/10)
+	}
+
+	if got := drain(h); !slices.Equal(got, []int{9, 6, 5, 4, 3, 2, 1, 1}) {
+		t.Errorf("Drain() = %v", got)
+	}
+}
+
+func TestIndexedHeapUpdate(t *testing.T) {
+	h := NewIndexedHeap[string, int]()
+	a := h.Push("a", 10)
+	b := h.Push("b", 20)
+	c := h.Push("c", 30)
+
+	h.Update(c, 5)  // to the front
+	h.Update(a, 25) // to the back
+	h.Update(b, 20) // unchanged
+
+	if got := drain(h); !slices.Equal(got, []string{"c", "b", "a"}) {
+		t.Errorf("Drain() = %v", got)
+	}
+}
+
+func TestIndexedHeapRemove(t *testing.T) {
+	h := NewIndexedHeap[int, int]()
+	handles := make([]Handle, 10)
+	for i := range handles {
+		handles[i] = h.Push(i, i)
+	}
+
+	h.Remove(handles[0]) // the front
+	h.Remove(handles[9]) // the last
+	h.Remove(handles[4]) // in between
+
+	if h.Contains(handles[4]) {
+		t.Errorf("Contains() = true after Remove")
+	}
+	if !h.Contains(handles[5]) {
+		t.Errorf("Contains() = false for an element in the heap")
+	}
+	if got := drain(h); !slices.Equal(got, []int{1, 2, 3, 5, 6, 7, 8}) {
+		t.Errorf("Drain() = %v", got)
+	}
+}
+
+func TestIndexedHeapContains(t *testing.T) {
+	h := NewIndexedHeap[string, int]()
+	a := h.Push("a", 1)
+	b := h.Push("b", 2)
+
+	if !h.Contains(a) || !h.Contains(b) {
+		t.Fatal("Contains() = false after Push")
+	}
+
+	h.Pop()
+	if h.Contains(a) {
+		t.Errorf("Contains() = true after the element was popped")
+	}
+	if !h.Contains(b) {
+		t.Errorf("Contains() = false for an element which wasn't popped")
+	}
+
+	// handles are never reused, a new element doesn't bring a popped one back
+	c := h.Push("c", 0)
+	if c == a || h.Contains(a) {
+		t.Errorf("Push() reused the handle of a popped element")
+	}
+	if h.Contains(Handle(-1)) || h.Contains(Handle(100)) {
+		t.Errorf("Contains() = true for an unknown handle")
+	}
+}
+
+func TestIndexedHeapUpdatePanics(t *testing.T) {
+	h := NewIndexedHeap[string, int]()
+	a := h.Push("a", 1)
+	h.Pop()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Update of a popped element didn't panic")
+		}
+	}()
+	h.Update(a, 0)
+}
+
+// TestIndexedHeapRandom checks a random mix of operations against a map of the priorities.
+func TestIndexedHeapRandom(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	h := NewIndexedHeap[int, int]()
+	prio := map[Handle]int{}
+	var handles []Handle
+
+	for i := range 2000 {
+		switch op := rnd.IntN(4); {
+		case op == 0 || len(prio) == 0:
+			p := rnd.IntN(100)
+			it := h.Push(i, p)
+			prio[it] = p
+			handles = append(handles, it)
+		case op == 1:
+			it := handles[rnd.IntN(len(handles))]
+			if _, ok := prio[it]; ok {
+				p := rnd.IntN(100)
+				h.Update(it, p)
+				prio[it] = p
+			}
+		case op == 2:
+			it := handles[rnd.IntN(len(handles))]
+			if _, ok := prio[it]; ok {
+				h.Remove(it)
+				delete(prio, it)
+			}
+		default:
+			want := slices.Min(slices.Collect(maps.Values(prio)))
+			if _, p := h.Pop(); p != want {
+				t.Fatalf("Pop() priority = %d, want %d", p, want)
+			}
+			for it, q := range prio {
+				if q == want && !h.Contains(it) {
+					delete(prio, it)
+					break
+				}
+			}
+		}
+
+		if h.Len() != len(prio) {
+			t.Fatalf("Len() = %d, want %d", h.Len(), len(prio))
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package collections
+
+import "iter"
+
+// Queue is a double-ended queue backed by a growable ring buffer. All operations run in amortised
+// constant time. The zero value is an empty queue ready to use.
+type Queue[T any] struct {
+	p, s int
+	data []T
+}
+
+// NewQueue creates a queue with room for size elements before it needs to grow.
+func NewQueue[T any](size int, elems ...T) *Queue[T] {
+	q := &Queue[T]{
+		data: make([]T, max(size, len(elems))),
+	}
+
+	for _, el := range elems {
+		q.PushBack(el)
+	}
+
+	return q
+}
+
+// ensureSpace grows the buffer so it can hold size additional elements.
+func (q *Queue[T]) ensureSpace(size int) {
+	if len(q.data)-q.s >= size {
+		return
+	}
+
+	data := make([]T, max(2*len(q.data), q.s+size, 8))
+	n := copy(data, q.data[q.p:min(q.p+q.s, len(q.data))])
+	copy(data[n:], q.data[:q.s-n])
+
+	q.p = 0
+	q.data = data
+}
+
+// index maps the i-th element of the queue to its position in the buffer.
+func (q *Queue[T]) index(i int) int {
+	i += q.p
+	if i >= len(q.data) {
+		i -= len(q.data)
+	}
+	return i
+}
+
+// Push appends an element to the back of the queue.
+func (q *Queue[T]) Push(el T) {
+	q.PushBack(el)
+}
+
+// PushBack appends an element to the back of the queue.
+func (q *Queue[T]) PushBack(el T) {
+	q.ensureSpace(1)
+	q.data[q.index(q.s)] = el
+	q.s++
+}
+
+// PushFront inserts an element in front of the queue.
+func (q *Queue[T]) PushFront(el T) {
+	q.ensureSpace(1)
+	q.p--
+	if q.p < 0 {
+		q.p += len(q.data)
+	}
+	q.data[q.p] = el
+	q.s++
+}
+
+// PopFront removes and returns the element in front of the queue. It panics if the queue is empty.
+func (q *Queue[T]) PopFront() T {
+	if q.s == 0 {
+		panic("PopFront called on empty queue")
+	}
+
+	var zero T
+	el := q.data[q.p]
+	q.data[q.p] = zero
+	q.p = q.index(1)
+	q.s--
+
+	return el
+}
+
+// PopBack removes and returns the element at the back of the queue. It panics if the queue is
+// empty.
+func (q *Queue[T]) PopBack() T {
+	if q.s == 0 {
+		panic("PopBack called on empty queue")
+	}
+
+	var zero T
+	i := q.index(q.s - 1)
+	el := q.data[i]
+	q.data[i] = zero
+	q.s--
+
+	return el
+}
+
+// Peek returns the element in front of the queue without removing it. It panics if the queue is
+// empty.
+func (q *Queue[T]) Peek() T {
+	if q.s == 0 {
+		panic("Peek called on empty queue")
+	}
+
+	return q.data[q.p]
+}
+
+// PeekBack returns the element at the back of the queue without removing it. It panics if the
+// queue is empty.
+func (q *Queue[T]) PeekBack() T {
+	if q.s == 0 {
+		panic("PeekBack called on empty queue")
+	}
+
+	return q.data[q.index(q.s-1)]
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	return q.s
+}
+
+// Clear removes all elements from the queue but keeps the allocated buffer.
+func (q *Queue[T]) Clear() {
+	clear(q.data)
+	q.p, q.s = 0, 0
+}
+
+// All iterates over the elements from front to back without removing them.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range q.s {
+			if !yield(q.data[q.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Drain iterates over the elements from front to back and removes them from the queue. Elements
+// pushed while iterating are part of the iteration, which makes it a natural fit for BFS.
+func (q *Queue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for q.s > 0 {
+			if !yield(q.PopFront()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQueueFIFO(t *testing.T) {
+	q := NewQueue(0, 1, 2, 3)
+	q.Push(4)
+
+	for want := 1; want <= 4; want++ {
+		if got := q.PopFront(); got != want {
+			t.Fatalf("PopFront() = %d, want %d", got, want)
+		}
+	}
+	if q.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0", q.Len())
+	}
+}
+
+func TestQueueWraparound(t *testing.T) {
+	q := NewQueue[int](4)
+
+	// move the front through the buffer several times without growing it
+	next, want := 0, 0
+	for range 10 {
+		for range 3 {
+			q.PushBack(next)
+			next++
+		}
+		for range 3 {
+			if got := q.PopFront(); got != want {
+				t.Fatalf("PopFront() = %d, want %d", got, want)
+			}
+			want++
+		}
+	}
+
+	if len(q.data) != 4 {
+		t.Errorf("buffer grew to %d, want 4", len(q.data))
+	}
+}
+
+func TestQueueGrowWrapped(t *testing.T) {
+	q := NewQueue[int](4)
+
+	// wrap the queue around the end of the buffer before it has to grow
+	q.PushBack(0)
+	q.PushBack(1)
+	q.PopFront()
+	q.PopFront()
+	for i := range 10 {
+		q.PushBack(i)
+	}
+
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("All() = %v after growing", got)
+	}
+}
+
+func TestQueueZeroValue(t *testing.T) {
+	var q Queue[string]
+	q.PushFront("b")
+	q.PushFront("a")
+	q.PushBack("c")
+
+	if got := slices.Collect(q.All()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("All() = %v", got)
+	}
+}
+
+func TestQueueDeque(t *testing.T) {
+	q := NewQueue[int](2)
+
+	q.PushBack(2)
+	q.PushFront(1)
+	q.PushFront(0)
+	q.PushBack(3)
+
+	if got := q.Peek(); got != 0 {
+		t.Errorf("Peek() = %d, want 0", got)
+	}
+	if got := q.PeekBack(); got != 3 {
+		t.Errorf("PeekBack() = %d, want 3", got)
+	}
+
+	for _, want := range []int{3, 2, 1, 0} {
+		if got := q.PopBack(); got != want {
+			t.Fatalf("PopBack() = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestQueueClear(t *testing.T) {
+	q := NewQueue(0, 1, 2, 3)
+	q.PopFront()
+	size := len(q.data)
+
+	q.Clear()
+	if q.Len() != 0 {
+		t.Fatalf("Len() = %d after Clear, want 0", q.Len())
+	}
+	if len(q.data) != size {
+		t.Errorf("Clear dropped the buffer")
+	}
+
+	q.PushBack(4)
+	if got := q.PopFront(); got != 4 {
+		t.Errorf("PopFront() = %d after Clear, want 4", got)
+	}
+}
+
+func TestQueueDrain(t *testing.T) {
+	q := NewQueue(2, 0, 1)
+
+	var got []int
+	for n := range q.Drain() {
+		got = append(got, n)
+		if n < 4 {
+			q.PushBack(n + 2)
+		}
+	}
+
+	if !slices.Equal(got, []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("Drain() = %v", got)
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d after Drain, want 0", q.Len())
+	}
+}
+
+func TestQueueEmptyPanics(t *testing.T) {
+	for name, f := range map[string]func(q *Queue[int]){
+		"PopFront": func(q *Queue[int]) { q.PopFront() },
+		"PopBack":  func(q *Queue[int]) { q.PopBack() },
+		"Peek":     func(q *Queue[int]) { q.Peek() },
+		"PeekBack": func(q *Queue[int]) { q.PeekBack() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s on an empty queue did not panic", name)
+				}
+			}()
+			f(&Queue[int]{})
+		})
+	}
+}
+
+// benchmarkSize is the number of elements kept in the queue, roughly the frontier of a BFS on a
+// puzzle grid.
+const benchmarkSize = 1000
+
+func BenchmarkQueue(b *testing.B) {
+	q := NewQueue[int](0)
+	for i := range benchmarkSize {
+		q.PushBack(i)
+	}
+
+	b.ResetTimer()
+	for i := range b.N {
+		q.PopFront()
+		q.PushBack(i)
+	}
+}
+
+// BenchmarkSliceQueue is the baseline, a slice used as a queue by appending to the back and
+// reslicing the front.
+func BenchmarkSliceQueue(b *testing.B) {
+	q := make([]int, 0, benchmarkSize)
+	for i := range benchmarkSize {
+		q = append(q, i)
+	}
+
+	b.ResetTimer()
+	for i := range b.N {
+		q = q[1:]
+		q = append(q, i)
+	}
+}
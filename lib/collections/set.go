@@ -0,0 +1,56 @@
+// Package collections contains generic container types shared by all puzzles.
+package collections
+
+type Set[T comparable] map[T]struct{}
+
+func SetFromSlice[T comparable, S ~[]T](sl S) Set[T] {
+	s := make(Set[T], len(sl))
+
+	for _, t := range sl {
+		s[t] = struct{}{}
+	}
+
+	return s
+}
+
+func (s Set[T]) Put(t T) {
+	s[t] = struct{}{}
+}
+
+func (s Set[T]) Has(t T) bool {
+	_, ok := s[t]
+	return ok
+}
+
+// Merge adds all elements of o to s.
+func (s Set[T]) Merge(o Set[T]) {
+	for t := range o {
+		s[t] = struct{}{}
+	}
+}
+
+// MergeWith adds all elements of o to s after transforming them with f.
+func (s Set[T]) MergeWith(o Set[T], f func(T) T) {
+	for t := range o {
+		s[f(t)] = struct{}{}
+	}
+}
+
+// Any returns an arbitrary element of the set or the zero value if the set is empty.
+func (s Set[T]) Any() T {
+	for t := range s {
+		return t
+	}
+
+	var t T
+	return t
+}
+
+// Copy returns a shallow copy of the set.
+func (s Set[T]) Copy() Set[T] {
+	c := make(Set[T], len(s))
+	for t := range s {
+		c[t] = struct{}{}
+	}
+	return c
+}
@@ -0,0 +1,47 @@
+// Package cycle finds repetitions in simulations which evolve a state step by step. States are
+// compared by a key, which makes it possible to use states which aren't comparable themselves
+// (maps, slices) and to compare only the part of a state which determines its future.
+//
+// The step function must return a new state and leave its argument untouched.
+package cycle
+
+// Find returns the number of steps before the sequence start, step(start), ... enters its cycle
+// (mu) and the length of the cycle (lambda). It uses Brent's algorithm: the tortoise teleports to
+// the hare whenever the hare has taken a power of two steps. Find gives up if no cycle is detected
+// within limit steps, so it can be used on sequences which may not repeat in time.
+func Find[S any, K comparable](start S, step func(S) S, key func(S) K, limit int) (mu, lambda int, ok bool) {
+	power, lambda := 1, 1
+	tortoise, hare := key(start), step(start)
+	for steps := 1; tortoise != key(hare); steps++ {
+		if steps >= limit {
+			return 0, 0, false
+		}
+
+		if power == lambda {
+			tortoise = key(hare)
+			power *= 2
+			lambda = 0
+		}
+		hare = step(hare)
+		lambda++
+	}
+
+	return prefix(start, step, key, lambda), lambda, true
+}
+
+// prefix returns the number of steps before a sequence with the given cycle length enters its
+// cycle.
+func prefix[S any, K comparable](start S, step func(S) S, key func(S) K, lambda int) (mu int) {
+	// move the hare lambda steps ahead, both meet at the start of the cycle
+	t, h := start, start
+	for range lambda {
+		h = step(h)
+	}
+	for key(t) != key(h) {
+		t = step(t)
+		h = step(h)
+		mu++
+	}
+
+	return mu
+}
@@ -0,0 +1,115 @@
+package cycle
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// rho returns a step function for the sequence 0, 1, ..., mu+lambda-1, mu, mu+1, ...
+func rho(mu, lambda int) func(int) int {
+	return func(x int) int {
+		if x+1 < mu+lambda {
+			return x + 1
+		}
+		return mu
+	}
+}
+
+func identity[T any](x T) T { return x }
+
+// naive detects the cycle by remembering every key.
+func naive[S any, K comparable](start S, step func(S) S, key func(S) K) (mu, lambda int) {
+	seen := map[K]int{}
+	s := start
+	for i := 0; ; i++ {
+		k := key(s)
+		if j, ok := seen[k]; ok {
+			return j, i - j
+		}
+		seen[k] = i
+		s = step(s)
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		mu, lambda int
+	}{
+		{0, 1},
+		{0, 2},
+		{0, 5},
+		{1, 1},
+		{3, 1},
+		{1, 2},
+		{7, 4},
+		{8, 8},
+		{100, 37},
+		{37, 100},
+		{1000, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("mu=%d lambda=%d", tt.mu, tt.lambda), func(t *testing.T) {
+			mu, lambda, ok := Find(0, rho(tt.mu, tt.lambda), identity, 10000)
+			if !ok || mu != tt.mu || lambda != tt.lambda {
+				t.Errorf("Find() = %d, %d, %t", mu, lambda, ok)
+			}
+		})
+	}
+}
+
+func TestFindLimit(t *testing.T) {
+	count := func(x int) int { return x + 1 }
+	if _, _, ok := Find(0, count, identity, 1000); ok {
+		t.Error("Find() detected a cycle in a sequence without one")
+	}
+
+	// a long tail needs more steps than the limit allows
+	if _, _, ok := Find(0, rho(500, 3), identity, 100); ok {
+		t.Error("Find() detected a cycle beyond the limit")
+	}
+	if mu, lambda, ok := Find(0, rho(500, 3), identity, 1000); !ok || mu != 500 || lambda != 3 {
+		t.Errorf("Find() = %d, %d, %t, want 500, 3, true", mu, lambda, ok)
+	}
+}
+
+// TestFindKey uses slices as states, which are only comparable through their key.
+func TestFindKey(t *testing.T) {
+	// rotate a slice, the first element is ignored by the key and counts up
+	step := func(s []int) []int {
+		next := append([]int{s[0] + 1}, s[2:]...)
+		return append(next, s[1])
+	}
+	key := func(s []int) string { return fmt.Sprint(s[1:]) }
+
+	start := []int{0, 1, 2, 3, 4}
+	mu, lambda, ok := Find(start, step, key, 100)
+	if !ok || mu != 0 || lambda != 4 {
+		t.Errorf("Find() = %d, %d, %t, want 0, 4, true", mu, lambda, ok)
+	}
+	if !slices.Equal(start, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Find() changed the start state to %v", start)
+	}
+}
+
+// TestFindRandom compares Find with the naive detection on random functions.
+func TestFindRandom(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(20, 23))
+
+	for range 200 {
+		f := make([]int, 1+rnd.IntN(300))
+		for i := range f {
+			f[i] = rnd.IntN(len(f))
+		}
+		step := func(x int) int { return f[x] }
+		start := rnd.IntN(len(f))
+
+		wantMu, wantLambda := naive(start, step, identity)
+		mu, lambda, ok := Find(start, step, identity, 2*len(f)+1)
+		if !ok || mu != wantMu || lambda != wantLambda {
+			t.Fatalf("Find() = %d, %d, %t, want %d, %d", mu, lambda, ok, wantMu, wantLambda)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package debug implements a small line based debugger for the puzzles which simulate a machine.
+// Commands are read from a reader, so a session can be scripted from a file:
+//
+//	break pc=6
+//	watch A
+//	continue
+//	print
+//	reset A=117440
+//	step 3
+package debug
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Register is a named part of the machine state shown by print and observed by watch.
+type Register struct {
+	Name  string
+	Value string
+}
+
+// Steppable is a machine which can be executed one step at a time.
+type Steppable interface {
+	// Step executes a single step. It returns false if the machine has halted.
+	Step() bool
+	// Position identifies the next step, breakpoints are set on it.
+	Position() int
+	// Next describes the next step.
+	Next() string
+	// State returns the registers of the machine.
+	State() []Register
+	// Restart resets the machine to its initial state, overriding the named registers.
+	Restart(values map[string]int) error
+}
+
+// Debugger executes commands against a machine.
+type Debugger struct {
+	Machine Steppable
+	Out     io.Writer
+	// Prompt is written before reading a command, leave it empty for scripts.
+	Prompt string
+
+	breaks  map[int]bool
+	watches map[string]string
+	watched []string
+	halted  bool
+	steps   int
+}
+
+// New creates a debugger writing its output to out.
+func New(m Steppable, out io.Writer) *Debugger {
+	return &Debugger{
+		Machine: m,
+		Out:     out,
+		breaks:  make(map[int]bool),
+		watches: make(map[string]string),
+	}
+}
+
+// Run reads commands line by line until the reader is exhausted or quit is entered. Empty lines
+// and lines starting with # are ignored. Errors of single commands are reported and don't stop
+// the session.
+func (d *Debugger) Run(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for {
+		if d.Prompt != "" {
+			fmt.Fprint(d.Out, d.Prompt)
+		}
+		if !s.Scan() {
+			return s.Err()
+		}
+
+		quit, err := d.Exec(s.Text())
+		if err != nil {
+			fmt.Fprintf(d.Out, "error: %s\n", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+}
+
+// Exec executes a single command. It returns true if the session should end.
+func (d *Debugger) Exec(line string) (bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return false, nil
+	}
+
+	cmd, args := fields[0], fields[1:]
+	switch cmd {
+	case "step", "s":
+		n := 1
+		if len(args) > 0 {
+			var err error
+			if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+				return false, fmt.Errorf("invalid step count %q", args[0])
+			}
+		}
+		return false, d.step(n)
+
+	case "continue", "c":
+		return false, d.step(-1)
+
+	case "break", "b":
+		pos, err := parseBreak(args)
+		if err != nil {
+			return false, err
+		}
+		d.breaks[pos] = true
+		fmt.Fprintf(d.Out, "breakpoint set at pc=%d\n", pos)
+
+	case "delete", "d":
+		pos, err := parseBreak(args)
+		if err != nil {
+			return false, err
+		}
+		if !d.breaks[pos] {
+			return false, fmt.Errorf("no breakpoint at pc=%d", pos)
+		}
+		delete(d.breaks, pos)
+
+	case "watch", "w":
+		if len(args) == 0 {
+			return false, errors.New("watch needs the name of a register")
+		}
+		for _, name := range args {
+			value, ok := d.lookup(name)
+			if !ok {
+				return false, fmt.Errorf("unknown register %q", name)
+			}
+			if _, ok := d.watches[name]; !ok {
+				d.watched = append(d.watched, name)
+			}
+			d.watches[name] = value
+			fmt.Fprintf(d.Out, "watching %s = %s\n", name, value)
+		}
+
+	case "print", "p":
+		return false, d.print(args)
+
+	case "reset", "r":
+		values := make(map[string]int, len(args))
+		for _, arg := range args {
+			name, raw, ok := strings.Cut(arg, "=")
+			if !ok {
+				return false, fmt.Errorf("invalid assignment %q, expected NAME=VALUE", arg)
+			}
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return false, fmt.Errorf("invalid value for %s: %w", name, err)
+			}
+			values[name] = v
+		}
+		if err := d.Machine.Restart(values); err != nil {
+			return false, err
+		}
+
+		d.halted = false
+		d.steps = 0
+		for _, name := range d.watched {
+			d.watches[name], _ = d.lookup(name)
+		}
+		d.location()
+
+	case "quit", "q":
+		return true, nil
+
+	case "help", "h":
+		fmt.Fprintln(d.Out, help)
+
+	default:
+		return false, fmt.Errorf("unknown command %q, try help", cmd)
+	}
+
+	return false, nil
+}
+
+const help = `step [n]          execute n steps (default 1)
+continue          run until a breakpoint, a watched register changes or the machine halts
+break pc=N        stop when continue reaches position N
+delete pc=N       remove the breakpoint
+watch NAME...     stop when the register changes
+print [NAME...]   show the registers
+reset [NAME=V...] restart the machine with the given registers
+quit              end the session`
+
+func parseBreak(args []string) (int, error) {
+	if len(args) != 1 || !strings.HasPrefix(args[0], "pc=") {
+		return 0, errors.New("expected pc=N")
+	}
+	pos, err := strconv.Atoi(strings.TrimPrefix(args[0], "pc="))
+	if err != nil {
+		return 0, fmt.Errorf("invalid position: %w", err)
+	}
+	return pos, nil
+}
+
+// step executes n steps or runs until the next stop if n is negative.
+func (d *Debugger) step(n int) error {
+	if d.halted {
+		return errors.New("the machine has halted, reset it to start over")
+	}
+
+	for i := 0; n < 0 || i < n; i++ {
+		prev := d.Machine.Position()
+		if !d.Machine.Step() {
+			d.halted = true
+			fmt.Fprintf(d.Out, "halted after %d steps\n", d.steps)
+			return nil
+		}
+		d.steps++
+
+		if d.checkWatches() {
+			break
+		}
+		// machines may stay at the same position for several steps, stop only on arrival
+		if pos := d.Machine.Position(); n < 0 && pos != prev && d.breaks[pos] {
+			fmt.Fprintf(d.Out, "breakpoint at pc=%d\n", pos)
+			break
+		}
+	}
+
+	d.location()
+	return nil
+}
+
+// checkWatches reports all watched registers which changed with the last step.
+func (d *Debugger) checkWatches() bool {
+	changed := false
+	for _, name := range d.watched {
+		value, _ := d.lookup(name)
+		if old := d.watches[name]; old != value {
+			fmt.Fprintf(d.Out, "%s changed: %s -> %s\n", name, old, value)
+			d.watches[name] = value
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (d *Debugger) location() {
+	fmt.Fprintf(d.Out, "pc=%d %s\n", d.Machine.Position(), d.Machine.Next())
+}
+
+func (d *Debugger) print(names []string) error {
+	state := d.Machine.State()
+	if len(names) == 0 {
+		for _, r := range state {
+			fmt.Fprintf(d.Out, "%s = %s\n", r.Name, r.Value)
+		}
+		return nil
+	}
+
+	for _, name := range names {
+		i := slices.IndexFunc(state, func(r Register) bool { return r.Name == name })
+		if i < 0 {
+			return fmt.Errorf("unknown register %q", name)
+		}
+		fmt.Fprintf(d.Out, "%s = %s\n", name, state[i].Value)
+	}
+	return nil
+}
+
+func (d *Debugger) lookup(name string) (string, bool) {
+	for _, r := range d.Machine.State() {
+		if r.Name == name {
+			return r.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,214 @@
+package debug
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// counter is a machine running the loop
+//
+//	0: A -= 1
+//	1: B += A      (takes two steps)
+//	2: jnz A 0
+//
+// Position 3 is past the end of the program, the machine halts there.
+type counter struct {
+	pc, a, b int
+	waiting  bool
+}
+
+func newCounter() *counter {
+	c := &counter{}
+	c.Restart(nil)
+	return c
+}
+
+func (c *counter) Step() bool {
+	switch c.pc {
+	case 0:
+		c.a--
+		c.pc++
+	case 1:
+		if c.waiting = !c.waiting; !c.waiting {
+			c.b += c.a
+			c.pc++
+		}
+	case 2:
+		c.pc = 3
+		if c.a != 0 {
+			c.pc = 0
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (c *counter) Position() int {
+	return c.pc
+}
+
+func (c *counter) Next() string {
+	return [...]string{"A -= 1", "B += A", "jnz A 0", "halt"}[c.pc]
+}
+
+func (c *counter) State() []Register {
+	return []Register{
+		{Name: "A", Value: strconv.Itoa(c.a)},
+		{Name: "B", Value: strconv.Itoa(c.b)},
+	}
+}
+
+func (c *counter) Restart(values map[string]int) error {
+	*c = counter{a: 3}
+	for name, v := range values {
+		switch name {
+		case "A":
+			c.a = v
+		case "B":
+			c.b = v
+		default:
+			return fmt.Errorf("unknown register %q", name)
+		}
+	}
+	return nil
+}
+
+func TestDebugger(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{
+			name:   "step",
+			script: "step\nstep 2\nprint\ns 3\np B",
+			want: `pc=1 B += A
+pc=2 jnz A 0
+A = 2
+B = 2
+pc=1 B += A
+B = 2
+`,
+		},
+		{
+			name:   "breakpoint",
+			script: "break pc=2\ncontinue\nc\ndelete pc=2\nprint\ncontinue",
+			want: `breakpoint set at pc=2
+breakpoint at pc=2
+pc=2 jnz A 0
+breakpoint at pc=2
+pc=2 jnz A 0
+A = 1
+B = 3
+halted after 12 steps
+`,
+		},
+		{
+			// position 1 takes two steps, the breakpoint triggers once per arrival
+			name:   "breakpoint on slow step",
+			script: "b pc=1\nc\nc\nprint A",
+			want: `breakpoint set at pc=1
+breakpoint at pc=1
+pc=1 B += A
+breakpoint at pc=1
+pc=1 B += A
+A = 1
+`,
+		},
+		{
+			name:   "watch",
+			script: "watch B A\nc\nc\nw B\nc",
+			want: `watching B = 0
+watching A = 3
+A changed: 3 -> 2
+pc=1 B += A
+B changed: 0 -> 2
+pc=2 jnz A 0
+watching B = 2
+A changed: 2 -> 1
+pc=1 B += A
+`,
+		},
+		{
+			name:   "halt",
+			script: "continue\nstep\nprint\nreset\nstep",
+			want: `halted after 12 steps
+error: the machine has halted, reset it to start over
+A = 0
+B = 3
+pc=0 A -= 1
+pc=1 B += A
+`,
+		},
+		{
+			name:   "reset",
+			script: "watch B\nstep 3\nreset A=1 B=10\nprint\ncontinue\nprint B",
+			want: `watching B = 0
+B changed: 0 -> 2
+pc=2 jnz A 0
+pc=0 A -= 1
+A = 1
+B = 10
+halted after 4 steps
+B = 10
+`,
+		},
+		{
+			name:   "errors",
+			script: "step 0\nstep x\nbreak 3\nbreak pc=x\ndelete pc=5\nwatch\nwatch C\nreset C=1\nreset A\nreset A=x\nprint C\nfoo\nprint A",
+			want: `error: invalid step count "0"
+error: invalid step count "x"
+error: expected pc=N
+error: invalid position: strconv.Atoi: parsing "x": invalid syntax
+error: no breakpoint at pc=5
+error: watch needs the name of a register
+error: unknown register "C"
+error: unknown register "C"
+error: invalid assignment "A", expected NAME=VALUE
+error: invalid value for A: strconv.Atoi: parsing "x": invalid syntax
+error: unknown register "C"
+error: unknown command "foo", try help
+A = 3
+`,
+		},
+		{
+			name:   "comments and quit",
+			script: "# a comment\n\n   \nstep\nquit\nstep",
+			want:   "pc=1 B += A\n",
+		},
+		{
+			name:   "help",
+			script: "h",
+			want:   help + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := strings.Builder{}
+			if err := New(newCounter(), &out).Run(strings.NewReader(tt.script)); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := out.String(); got != tt.want {
+				t.Errorf("transcript:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDebuggerPrompt(t *testing.T) {
+	out := strings.Builder{}
+	d := New(newCounter(), &out)
+	d.Prompt = "> "
+
+	if err := d.Run(strings.NewReader("step\nq\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "> pc=1 B += A\n> "; got != want {
+		t.Errorf("transcript = %q, want %q", got, want)
+	}
+}
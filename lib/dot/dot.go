@@ -0,0 +1,166 @@
+// Package dot writes graphs in the DOT language of Graphviz, e.g. to render them with
+// "dot -Tsvg -o graph.svg graph.dot".
+package dot
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Grapher is implemented by puzzle types which can be exported as a graph.
+type Grapher interface {
+	Graph() *Graph
+}
+
+// Node is a vertex of the graph. The label defaults to the ID.
+type Node struct {
+	ID        string
+	Label     string
+	Shape     string
+	Highlight bool
+}
+
+// Edge connects two nodes by their IDs.
+type Edge struct {
+	From, To  string
+	Label     string
+	Highlight bool
+}
+
+// Graph is a list of nodes and edges. Edges may reference nodes which were never added.
+type Graph struct {
+	Name     string
+	Directed bool
+	Nodes    []*Node
+	Edges    []*Edge
+
+	nodes map[string]*Node
+}
+
+// New creates an empty graph.
+func New(name string, directed bool) *Graph {
+	return &Graph{
+		Name:     name,
+		Directed: directed,
+		nodes:    make(map[string]*Node),
+	}
+}
+
+// Graph returns the graph itself, so it can be passed wherever a Grapher is expected.
+func (g *Graph) Graph() *Graph {
+	return g
+}
+
+// Node adds a node or returns the existing node with the ID.
+func (g *Graph) Node(id, label string) *Node {
+	if n, ok := g.nodes[id]; ok {
+		return n
+	}
+
+	n := &Node{ID: id, Label: label}
+	g.nodes[id] = n
+	g.Nodes = append(g.Nodes, n)
+
+	return n
+}
+
+// Edge adds an edge between two nodes.
+func (g *Graph) Edge(from, to, label string) *Edge {
+	e := &Edge{From: from, To: to, Label: label}
+	g.Edges = append(g.Edges, e)
+	return e
+}
+
+// HighlightNodes highlights the nodes with the given IDs.
+func (g *Graph) HighlightNodes(ids ...string) {
+	for _, n := range g.Nodes {
+		if slices.Contains(ids, n.ID) {
+			n.Highlight = true
+		}
+	}
+}
+
+// HighlightPath highlights the nodes and the edges along the path.
+func (g *Graph) HighlightPath(ids ...string) {
+	g.HighlightNodes(ids...)
+
+	for i := 1; i < len(ids); i++ {
+		for _, e := range g.Edges {
+			if e.From == ids[i-1] && e.To == ids[i] || !g.Directed && e.From == ids[i] && e.To == ids[i-1] {
+				e.Highlight = true
+			}
+		}
+	}
+}
+
+// HighlightClique highlights the nodes and all edges between them.
+func (g *Graph) HighlightClique(ids ...string) {
+	g.HighlightNodes(ids...)
+
+	for _, e := range g.Edges {
+		if slices.Contains(ids, e.From) && slices.Contains(ids, e.To) {
+			e.Highlight = true
+		}
+	}
+}
+
+const highlight = `color="red", fontcolor="red", penwidth=2`
+
+// Write renders the graph in the DOT language.
+func Write(w io.Writer, gr Grapher) error {
+	g := gr.Graph()
+	bw := bufio.NewWriter(w)
+
+	kind, arrow := "graph", " -- "
+	if g.Directed {
+		kind, arrow = "digraph", " -> "
+	}
+
+	bw.WriteString(kind + " " + quote(g.Name) + " {\n")
+
+	for _, n := range g.Nodes {
+		var attrs []string
+		if n.Label != "" {
+			attrs = append(attrs, "label="+quote(n.Label))
+		}
+		if n.Shape != "" {
+			attrs = append(attrs, "shape="+quote(n.Shape))
+		}
+		if n.Highlight {
+			attrs = append(attrs, highlight)
+		}
+		bw.WriteString("  " + quote(n.ID) + attributes(attrs) + ";\n")
+	}
+
+	for _, e := range g.Edges {
+		var attrs []string
+		if e.Label != "" {
+			attrs = append(attrs, "label="+quote(e.Label))
+		}
+		if e.Highlight {
+			attrs = append(attrs, highlight)
+		}
+		bw.WriteString("  " + quote(e.From) + arrow + quote(e.To) + attributes(attrs) + ";\n")
+	}
+
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
+
+func attributes(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+// quote turns s into a DOT string, newlines become line breaks in labels.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
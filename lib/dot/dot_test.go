@@ -0,0 +1,88 @@
+package dot
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the expected graphs")
+
+// golden compares the rendered graph with testdata/name.dot.
+func golden(t *testing.T, name string, g *Graph) {
+	t.Helper()
+
+	var out bytes.Buffer
+	if err := Write(&out, g); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join("testdata", name+".dot")
+	if *update {
+		if err := os.WriteFile(file, out.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("graph differs from %s:\n%s", file, out.String())
+	}
+}
+
+func TestWriteQuoting(t *testing.T) {
+	g := New(`say "hi"`, true)
+	g.Node("plain", "")
+	g.Node("quote", `a "quoted" label`)
+	g.Node(`back\slash`, `C:\dir\`)
+	g.Node("lines", "first\nsecond\n")
+	g.Node("all", "\"\\\n\\n\"")
+	g.Edge("plain", "quote", "")
+	g.Edge(`back\slash`, "lines", "x -> \"y\"\nz")
+
+	golden(t, "quoting", g)
+}
+
+func TestWriteAttributes(t *testing.T) {
+	g := New("attrs", false)
+
+	// every combination of attributes, they are always written as label, shape, highlight
+	n := g.Node("a", "A")
+	n.Shape = "box"
+	n.Highlight = true
+	g.Node("b", "").Shape = "circle"
+	g.Node("c", "C").Highlight = true
+	g.Node("d", "")
+
+	g.Edge("a", "b", "ab")
+	g.Edge("b", "c", "")
+	g.Edge("c", "d", "cd")
+	g.Edge("d", "e", "")
+	g.HighlightPath("c", "b")
+
+	// adding an existing node keeps the first label and position
+	if n := g.Node("a", "other"); n.Label != "A" || len(g.Nodes) != 4 {
+		t.Errorf("Node(a) = %+v with %d nodes", n, len(g.Nodes))
+	}
+
+	golden(t, "attributes", g)
+}
+
+func TestHighlightClique(t *testing.T) {
+	g := New("clique", false)
+	for _, id := range []string{"a", "b", "c", "d"} {
+		g.Node(id, "")
+	}
+	g.Edge("a", "b", "")
+	g.Edge("b", "c", "")
+	g.Edge("c", "a", "")
+	g.Edge("c", "d", "")
+	g.HighlightClique("a", "b", "c")
+
+	golden(t, "clique", g)
+}
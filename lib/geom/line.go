@@ -0,0 +1,138 @@
+package geom
+
+import (
+	"math/big"
+
+	"github.com/noxer/aoc/lib/mathx"
+)
+
+// Relation describes how two lines are positioned relative to each other.
+type Relation int
+
+const (
+	Skew       Relation = iota // the lines neither intersect nor are parallel (3D only)
+	Crossing                   // the lines intersect in a single point
+	Parallel                   // the lines have the same direction but don't touch
+	Coincident                 // the lines are the same
+)
+
+var relationNames = [...]string{"skew", "crossing", "parallel", "coincident"}
+
+func (r Relation) String() string {
+	return relationNames[r]
+}
+
+// Line3 is the line through P in direction D, its points are P + t*D. All computations are exact,
+// so coordinates in the order of 1e15 work fine.
+type Line3 struct {
+	P, D Vec3
+}
+
+// At returns the point P + t*D.
+func (l Line3) At(t *big.Rat) RatVec3 {
+	return l.P.Rat().Add(l.D.Rat().Mul(t))
+}
+
+// Intersect2 intersects the projections of the lines onto the XY plane. For crossing lines t and
+// s are the parameters of the intersection on l and o respectively.
+func (l Line3) Intersect2(o Line3) (t, s *big.Rat, rel Relation) {
+	dp := o.P.Sub(l.P)
+
+	// t*l.D - s*o.D = o.P - l.P
+	t, s, ok := mathx.CramerRat(
+		big.NewInt(int64(l.D.X)), big.NewInt(int64(-o.D.X)),
+		big.NewInt(int64(l.D.Y)), big.NewInt(int64(-o.D.Y)),
+		big.NewInt(int64(dp.X)), big.NewInt(int64(dp.Y)),
+	)
+	if ok {
+		return t, s, Crossing
+	}
+
+	// o.P lies on l if the offset is parallel to the direction
+	cross := new(big.Int).Mul(big.NewInt(int64(dp.X)), big.NewInt(int64(l.D.Y)))
+	cross.Sub(cross, new(big.Int).Mul(big.NewInt(int64(dp.Y)), big.NewInt(int64(l.D.X))))
+	if cross.Sign() == 0 {
+		return nil, nil, Coincident
+	}
+	return nil, nil, Parallel
+}
+
+// Intersect intersects the lines in 3D. For crossing lines t and s are the parameters of the
+// intersection on l and o respectively.
+func (l Line3) Intersect(o Line3) (t, s *big.Rat, rel Relation) {
+	d1, d2 := l.D.Rat(), o.D.Rat()
+	dp := o.P.Sub(l.P).Rat()
+
+	n := d1.Cross(d2)
+	if n.Zero() {
+		if dp.Cross(d1).Zero() {
+			return nil, nil, Coincident
+		}
+		return nil, nil, Parallel
+	}
+
+	// crossing lines lie in a common plane
+	if dp.Dot(n).Sign() != 0 {
+		return nil, nil, Skew
+	}
+
+	nn := n.Dot(n)
+	t = dp.Cross(d2).Dot(n)
+	s = dp.Cross(d1).Dot(n)
+
+	return t.Quo(t, nn), s.Quo(s, nn), Crossing
+}
+
+// ThroughAll finds the line p + t*d which meets every line at the same parameter, i.e. for every
+// line there is a t with p + t*d = P + t*D. ok is false if there is no such line or the lines
+// don't determine it.
+func ThroughAll(lines []Line3) (p, d RatVec3, ok bool) {
+	// (p - P_i) x (d - D_i) = 0 for all i. The term p x d is the same for all lines, subtracting
+	// the equations of two lines gives three linear equations in p and d.
+	for j := 1; j < len(lines); j++ {
+		for k := j + 1; k < len(lines); k++ {
+			a := make([][]*big.Rat, 0, 6)
+			b := make([]*big.Rat, 0, 6)
+			a, b = pairEquations(a, b, lines[0], lines[j])
+			a, b = pairEquations(a, b, lines[0], lines[k])
+
+			x, ok := mathx.SolveRat(a, b)
+			if !ok {
+				continue
+			}
+
+			p = RatVec3{X: x[0], Y: x[1], Z: x[2]}
+			d = RatVec3{X: x[3], Y: x[4], Z: x[5]}
+
+			for _, l := range lines {
+				if !p.Sub(l.P.Rat()).Cross(d.Sub(l.D.Rat())).Zero() {
+					return RatVec3{}, RatVec3{}, false
+				}
+			}
+
+			return p, d, true
+		}
+	}
+
+	return RatVec3{}, RatVec3{}, false
+}
+
+// pairEquations appends the equations p x (D_j - D_i) + (P_j - P_i) x d = P_j x D_j - P_i x D_i
+// with the unknowns p and d.
+func pairEquations(a [][]*big.Rat, b []*big.Rat, li, lj Line3) ([][]*big.Rat, []*big.Rat) {
+	w := lj.D.Rat().Sub(li.D.Rat())
+	q := lj.P.Rat().Sub(li.P.Rat())
+	r := lj.P.Rat().Cross(lj.D.Rat()).Sub(li.P.Rat().Cross(li.D.Rat()))
+
+	zero := new(big.Rat)
+	neg := func(x *big.Rat) *big.Rat { return new(big.Rat).Neg(x) }
+
+	a = append(a,
+		[]*big.Rat{zero, w.Z, neg(w.Y), zero, neg(q.Z), q.Y},
+		[]*big.Rat{neg(w.Z), zero, w.X, q.Z, zero, neg(q.X)},
+		[]*big.Rat{w.Y, neg(w.X), zero, neg(q.Y), q.X, zero},
+	)
+	b = append(b, r.X, r.Y, r.Z)
+
+	return a, b
+}
@@ -0,0 +1,135 @@
+package geom
+
+import (
+	"math/big"
+	"testing"
+)
+
+func line(px, py, pz, dx, dy, dz int) Line3 {
+	return Line3{P: Vec3{X: px, Y: py, Z: pz}, D: Vec3{X: dx, Y: dy, Z: dz}}
+}
+
+func TestIntersect2(t *testing.T) {
+	tests := []struct {
+		name string
+		l, o Line3
+		rel  Relation
+		t, s string
+	}{
+		{"crossing", line(0, 0, 0, 1, 1, 0), line(4, 0, 0, -1, 1, 0), Crossing, "2", "2"},
+		{"crossing in the past", line(0, 0, 0, 1, 0, 0), line(3, 5, 0, 0, 2, 0), Crossing, "3", "-5/2"},
+		// Z is ignored, the lines are skew in 3D
+		{"crossing projection", line(0, 0, 0, 1, 0, 0), line(0, 1, 1, 0, 1, 0), Crossing, "0", "-1"},
+		{"parallel", line(0, 0, 0, 1, 1, 0), line(1, 0, 0, 2, 2, 5), Parallel, "", ""},
+		{"coincident", line(0, 0, 0, 1, 1, 0), line(3, 3, 9, -2, -2, 1), Coincident, "", ""},
+		{"large coordinates", line(1e15, 0, 0, -1, 1, 0), line(0, 0, 0, 1, 1, 0), Crossing, "500000000000000", "500000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ti, si, rel := tt.l.Intersect2(tt.o)
+			if rel != tt.rel {
+				t.Fatalf("Intersect2() = %s, want %s", rel, tt.rel)
+			}
+			if rel != Crossing {
+				return
+			}
+
+			if ti.RatString() != tt.t || si.RatString() != tt.s {
+				t.Errorf("Intersect2() = %s, %s, want %s, %s", ti.RatString(), si.RatString(), tt.t, tt.s)
+			}
+
+			// the projections meet at the parameters
+			p, q := tt.l.At(ti), tt.o.At(si)
+			if p.X.Cmp(q.X) != 0 || p.Y.Cmp(q.Y) != 0 {
+				t.Errorf("At() = %s and %s", p, q)
+			}
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name string
+		l, o Line3
+		rel  Relation
+		t, s string
+	}{
+		{"crossing", line(1, 2, 3, 1, 0, 0), line(4, 0, 3, 0, 1, 0), Crossing, "3", "2"},
+		{"crossing diagonal", line(0, 0, 0, 1, 1, 1), line(2, 2, 0, 0, 0, 1), Crossing, "2", "2"},
+		{"fractional", line(0, 0, 0, 2, 0, 0), line(1, -1, 0, 0, 3, 0), Crossing, "1/2", "1/3"},
+		{"skew", line(0, 0, 0, 1, 0, 0), line(0, 1, 1, 0, 1, 0), Skew, "", ""},
+		{"parallel", line(0, 0, 0, 1, 2, 3), line(1, 0, 0, -2, -4, -6), Parallel, "", ""},
+		{"coincident", line(0, 0, 0, 1, 2, 3), line(2, 4, 6, 3, 6, 9), Coincident, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ti, si, rel := tt.l.Intersect(tt.o)
+			if rel != tt.rel {
+				t.Fatalf("Intersect() = %s, want %s", rel, tt.rel)
+			}
+			if rel != Crossing {
+				return
+			}
+
+			if ti.RatString() != tt.t || si.RatString() != tt.s {
+				t.Errorf("Intersect() = %s, %s, want %s, %s", ti.RatString(), si.RatString(), tt.t, tt.s)
+			}
+			if p, q := tt.l.At(ti), tt.o.At(si); !p.Equal(q) {
+				t.Errorf("At() = %s and %s", p, q)
+			}
+		})
+	}
+}
+
+// hail is the example of 2023 day 24, the rock is thrown from 24, 13, 10 with velocity -3, 1, 2.
+var hail = []Line3{
+	line(19, 13, 30, -2, 1, -2),
+	line(18, 19, 22, -1, -1, -2),
+	line(20, 25, 34, -2, -2, -4),
+	line(12, 31, 28, -1, -2, -1),
+	line(20, 19, 15, 1, -5, -3),
+}
+
+func TestThroughAll(t *testing.T) {
+	p, d, ok := ThroughAll(hail)
+	if !ok {
+		t.Fatal("ThroughAll() found no line")
+	}
+
+	pi, ok1 := p.Int()
+	di, ok2 := d.Int()
+	if !ok1 || !ok2 || pi != (Vec3{X: 24, Y: 13, Z: 10}) || di != (Vec3{X: -3, Y: 1, Z: 2}) {
+		t.Errorf("ThroughAll() = %s, %s", p, d)
+	}
+
+	// every line is hit at the same time by the rock and the hailstone
+	rock := Line3{P: pi, D: di}
+	for _, l := range hail {
+		ti, _, rel := rock.Intersect(l)
+		if rel != Crossing || !rock.At(ti).Equal(l.At(ti)) {
+			t.Errorf("the rock misses %v", l)
+		}
+	}
+}
+
+func TestThroughAllFails(t *testing.T) {
+	// a hailstone which the rock of the example doesn't hit
+	lines := append(append([]Line3(nil), hail...), line(0, 0, 0, 1, 1, 1))
+	if p, d, ok := ThroughAll(lines); ok {
+		t.Errorf("ThroughAll() = %s, %s, want no line", p, d)
+	}
+
+	// two lines don't determine the throw
+	if p, d, ok := ThroughAll(hail[:2]); ok {
+		t.Errorf("ThroughAll() = %s, %s for two lines", p, d)
+	}
+}
+
+func TestAt(t *testing.T) {
+	l := line(1, 2, 3, 2, -4, 6)
+	if got := l.At(big.NewRat(1, 2)); !got.Equal(Vec3{X: 2, Y: 0, Z: 6}.Rat()) {
+		t.Errorf("At(1/2) = %s", got)
+	}
+}
@@ -0,0 +1,55 @@
+package geom
+
+import "github.com/noxer/aoc/lib/mathx"
+
+// Polygon is a closed simple polygon given by its vertices in order. The last vertex is connected
+// back to the first one.
+type Polygon []Vec
+
+// DoubleArea returns twice the area enclosed by the polygon using the shoelace formula. It is
+// positive for counter-clockwise and negative for clockwise polygons (with Y pointing up).
+func (p Polygon) DoubleArea() int {
+	sum := 0
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		sum += a.X*b.Y - b.X*a.Y
+	}
+	return sum
+}
+
+// Area returns the area enclosed by the polygon. For vertices on the lattice the area is a
+// multiple of 1/2, it is rounded down.
+func (p Polygon) Area() int {
+	return mathx.Abs(p.DoubleArea()) / 2
+}
+
+// Perimeter returns the length of the boundary. It is exact for rectilinear polygons only.
+func (p Polygon) Perimeter() int {
+	sum := 0
+	for i, a := range p {
+		d := p[(i+1)%len(p)].Sub(a)
+		sum += mathx.Abs(d.X) + mathx.Abs(d.Y)
+	}
+	return sum
+}
+
+// BoundaryPoints returns the number of lattice points on the boundary.
+func (p Polygon) BoundaryPoints() int {
+	sum := 0
+	for i, a := range p {
+		d := p[(i+1)%len(p)].Sub(a)
+		sum += mathx.GCD(d.X, d.Y)
+	}
+	return sum
+}
+
+// InteriorPoints returns the number of lattice points strictly inside the polygon using Pick's
+// theorem: A = I + B/2 - 1.
+func (p Polygon) InteriorPoints() int {
+	return (mathx.Abs(p.DoubleArea())-p.BoundaryPoints())/2 + 1
+}
+
+// LatticePoints returns the number of lattice points inside or on the boundary of the polygon.
+func (p Polygon) LatticePoints() int {
+	return p.InteriorPoints() + p.BoundaryPoints()
+}
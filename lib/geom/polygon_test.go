@@ -0,0 +1,72 @@
+package geom
+
+import "testing"
+
+func TestPolygon(t *testing.T) {
+	tests := []struct {
+		name                              string
+		p                                 Polygon
+		doubleArea, area, perimeter       int
+		boundary, interior, latticePoints int
+	}{
+		{
+			"rectangle",
+			Polygon{{0, 0}, {4, 0}, {4, 3}, {0, 3}},
+			24, 12, 14, 14, 6, 20,
+		},
+		{
+			"clockwise rectangle",
+			Polygon{{0, 0}, {0, 3}, {4, 3}, {4, 0}},
+			-24, 12, 14, 14, 6, 20,
+		},
+		{
+			// the interior points are (1, 1), (1, 2) and (2, 1)
+			"triangle",
+			Polygon{{0, 0}, {4, 0}, {0, 4}},
+			16, 8, 0, 12, 3, 15,
+		},
+		{
+			"half unit triangle",
+			Polygon{{0, 0}, {1, 0}, {0, 1}},
+			1, 0, 0, 3, 0, 3,
+		},
+		{
+			// a 3x3 square with the top right 2x2 corner cut out
+			"L shape",
+			Polygon{{0, 0}, {3, 0}, {3, 1}, {1, 1}, {1, 3}, {0, 3}},
+			10, 5, 12, 12, 0, 12,
+		},
+		{
+			"large",
+			Polygon{{-1e6, -1e6}, {1e6, -1e6}, {1e6, 1e6}, {-1e6, 1e6}},
+			8e12, 4e12, 8e6, 8e6, 3999996000001, 4000004000001,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.DoubleArea(); got != tt.doubleArea {
+				t.Errorf("DoubleArea() = %d, want %d", got, tt.doubleArea)
+			}
+			if got := tt.p.Area(); got != tt.area {
+				t.Errorf("Area() = %d, want %d", got, tt.area)
+			}
+			if got := tt.p.BoundaryPoints(); got != tt.boundary {
+				t.Errorf("BoundaryPoints() = %d, want %d", got, tt.boundary)
+			}
+			if got := tt.p.InteriorPoints(); got != tt.interior {
+				t.Errorf("InteriorPoints() = %d, want %d", got, tt.interior)
+			}
+			if got := tt.p.LatticePoints(); got != tt.latticePoints {
+				t.Errorf("LatticePoints() = %d, want %d", got, tt.latticePoints)
+			}
+
+			// the perimeter is only exact for rectilinear polygons
+			if tt.perimeter != 0 {
+				if got := tt.p.Perimeter(); got != tt.perimeter {
+					t.Errorf("Perimeter() = %d, want %d", got, tt.perimeter)
+				}
+			}
+		})
+	}
+}
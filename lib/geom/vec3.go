@@ -0,0 +1,110 @@
+package geom
+
+import "math/big"
+
+type Vec3 struct {
+	X, Y, Z int
+}
+
+func (v Vec3) Add(o Vec3) Vec3 {
+	return Vec3{v.X + o.X, v.Y + o.Y, v.Z + o.Z}
+}
+
+func (v Vec3) Sub(o Vec3) Vec3 {
+	return Vec3{v.X - o.X, v.Y - o.Y, v.Z - o.Z}
+}
+
+func (v Vec3) Mul(s int) Vec3 {
+	return Vec3{v.X * s, v.Y * s, v.Z * s}
+}
+
+func (v Vec3) Zero() bool {
+	return v.X == 0 && v.Y == 0 && v.Z == 0
+}
+
+// Rat converts the vector to rationals for exact computations.
+func (v Vec3) Rat() RatVec3 {
+	return RatVec3{
+		X: new(big.Rat).SetInt64(int64(v.X)),
+		Y: new(big.Rat).SetInt64(int64(v.Y)),
+		Z: new(big.Rat).SetInt64(int64(v.Z)),
+	}
+}
+
+// RatVec3 is a vector with rational coordinates. The operations allocate new values and leave
+// their operands untouched.
+type RatVec3 struct {
+	X, Y, Z *big.Rat
+}
+
+func (v RatVec3) Add(o RatVec3) RatVec3 {
+	return RatVec3{
+		X: new(big.Rat).Add(v.X, o.X),
+		Y: new(big.Rat).Add(v.Y, o.Y),
+		Z: new(big.Rat).Add(v.Z, o.Z),
+	}
+}
+
+func (v RatVec3) Sub(o RatVec3) RatVec3 {
+	return RatVec3{
+		X: new(big.Rat).Sub(v.X, o.X),
+		Y: new(big.Rat).Sub(v.Y, o.Y),
+		Z: new(big.Rat).Sub(v.Z, o.Z),
+	}
+}
+
+func (v RatVec3) Mul(s *big.Rat) RatVec3 {
+	return RatVec3{
+		X: new(big.Rat).Mul(v.X, s),
+		Y: new(big.Rat).Mul(v.Y, s),
+		Z: new(big.Rat).Mul(v.Z, s),
+	}
+}
+
+func (v RatVec3) Dot(o RatVec3) *big.Rat {
+	d := new(big.Rat).Mul(v.X, o.X)
+	d.Add(d, new(big.Rat).Mul(v.Y, o.Y))
+	return d.Add(d, new(big.Rat).Mul(v.Z, o.Z))
+}
+
+func (v RatVec3) Cross(o RatVec3) RatVec3 {
+	return RatVec3{
+		X: ratDet2(v.Y, v.Z, o.Y, o.Z),
+		Y: ratDet2(v.Z, v.X, o.Z, o.X),
+		Z: ratDet2(v.X, v.Y, o.X, o.Y),
+	}
+}
+
+func (v RatVec3) Zero() bool {
+	return v.X.Sign() == 0 && v.Y.Sign() == 0 && v.Z.Sign() == 0
+}
+
+func (v RatVec3) Equal(o RatVec3) bool {
+	return v.X.Cmp(o.X) == 0 && v.Y.Cmp(o.Y) == 0 && v.Z.Cmp(o.Z) == 0
+}
+
+// Int converts the vector back to integers. ok is false if a coordinate isn't an integer or
+// doesn't fit into an int.
+func (v RatVec3) Int() (Vec3, bool) {
+	x, okX := ratInt(v.X)
+	y, okY := ratInt(v.Y)
+	z, okZ := ratInt(v.Z)
+	return Vec3{x, y, z}, okX && okY && okZ
+}
+
+func (v RatVec3) String() string {
+	return v.X.RatString() + ", " + v.Y.RatString() + ", " + v.Z.RatString()
+}
+
+func ratInt(r *big.Rat) (int, bool) {
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
+
+// ratDet2 returns a*d - b*c.
+func ratDet2(a, b, c, d *big.Rat) *big.Rat {
+	ad := new(big.Rat).Mul(a, d)
+	return ad.Sub(ad, new(big.Rat).Mul(b, c))
+}
@@ -1,4 +1,5 @@
-package utils
+// Package geom contains the geometry primitives shared by all puzzles.
+package geom
 
 var Directions = []Vec{
 	{X: -1}, // left / west
@@ -7,6 +8,17 @@ var Directions = []Vec{
 	{Y: +1}, // down / south
 }
 
+var Directions8 = []Vec{
+	{X: -1},        // left / west
+	{X: +1},        // right / east
+	{Y: -1},        // up / north
+	{Y: +1},        // down / south
+	{X: -1, Y: -1}, // north west
+	{X: +1, Y: -1}, // north east
+	{X: -1, Y: +1}, // south west
+	{X: +1, Y: +1}, // south east
+}
+
 type Vec struct {
 	X, Y int
 }
@@ -0,0 +1,67 @@
+// Package grid contains helpers for character maps as they are commonly found in puzzle inputs.
+package grid
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+
+	"github.com/noxer/aoc/lib/geom"
+)
+
+// ReadMap reads a character map from a file, skipping all bytes contained in ignore.
+func ReadMap(name string, ignore ...byte) (map[geom.Vec]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+
+	y := 0
+	m := make(map[geom.Vec]byte)
+	for s.Scan() {
+		for x, b := range s.Bytes() {
+			if bytes.Contains(ignore, []byte{b}) {
+				continue
+			}
+
+			m[geom.Vec{X: x, Y: y}] = b
+		}
+
+		y++
+	}
+
+	return m, s.Err()
+}
+
+// ReadMapWithSize works like ReadMap but also returns the width and height of the map.
+func ReadMapWithSize(name string, ignore ...byte) (map[geom.Vec]byte, geom.Vec, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, geom.Vec{}, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+
+	y := 0
+	m := make(map[geom.Vec]byte)
+	size := geom.Vec{}
+	for s.Scan() {
+		for x, b := range s.Bytes() {
+			if bytes.Contains(ignore, []byte{b}) {
+				continue
+			}
+
+			m[geom.Vec{X: x, Y: y}] = b
+		}
+
+		y++
+		size.X = max(size.X, len(s.Bytes()))
+	}
+
+	size.Y = y
+	return m, size, s.Err()
+}
@@ -0,0 +1,248 @@
+package grid
+
+import (
+	"bufio"
+	"fmt"
+	"iter"
+	"os"
+	"strings"
+
+	"github.com/noxer/aoc/lib/geom"
+)
+
+// Grid is a dense, slice-backed two dimensional map. The zero value is an empty grid.
+type Grid[T any] struct {
+	width, height int
+	cells         []T
+}
+
+// New creates a grid of the given size with all cells set to the zero value.
+func New[T any](width, height int) Grid[T] {
+	return Grid[T]{
+		width:  width,
+		height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+// NewFilled creates a grid of the given size with all cells set to v.
+func NewFilled[T any](width, height int, v T) Grid[T] {
+	g := New[T](width, height)
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+	return g
+}
+
+// Read loads a character map from a file. Lines shorter than the longest line are padded with
+// spaces.
+func Read(name string) (Grid[byte], error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Grid[byte]{}, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+
+	var lines [][]byte
+	width := 0
+	for s.Scan() {
+		line := append([]byte(nil), s.Bytes()...)
+		lines = append(lines, line)
+		width = max(width, len(line))
+	}
+	if err = s.Err(); err != nil {
+		return Grid[byte]{}, err
+	}
+
+	// ignore trailing empty lines
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	g := NewFilled(width, len(lines), byte(' '))
+	for y, line := range lines {
+		copy(g.cells[y*width:], line)
+	}
+
+	return g, nil
+}
+
+// Width returns the number of columns.
+func (g Grid[T]) Width() int {
+	return g.width
+}
+
+// Height returns the number of rows.
+func (g Grid[T]) Height() int {
+	return g.height
+}
+
+// Size returns width and height of the grid as a vector.
+func (g Grid[T]) Size() geom.Vec {
+	return geom.Vec{X: g.width, Y: g.height}
+}
+
+// InBounds reports whether the position lies within the grid.
+func (g Grid[T]) InBounds(p geom.Vec) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// Get returns the value at the position. It returns false if the position is out of bounds.
+func (g Grid[T]) Get(p geom.Vec) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+
+	return g.cells[p.Y*g.width+p.X], true
+}
+
+// At returns the value at the position or the zero value if the position is out of bounds.
+func (g Grid[T]) At(p geom.Vec) T {
+	v, _ := g.Get(p)
+	return v
+}
+
+// Set changes the value at the position. It returns false if the position is out of bounds.
+func (g Grid[T]) Set(p geom.Vec, v T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+
+	g.cells[p.Y*g.width+p.X] = v
+	return true
+}
+
+// All iterates over all cells row by row.
+func (g Grid[T]) All() iter.Seq2[geom.Vec, T] {
+	return func(yield func(geom.Vec, T) bool) {
+		for i, v := range g.cells {
+			if !yield(geom.Vec{X: i % g.width, Y: i / g.width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Row iterates over the cells of row y from left to right.
+func (g Grid[T]) Row(y int) iter.Seq2[geom.Vec, T] {
+	return func(yield func(geom.Vec, T) bool) {
+		if y < 0 || y >= g.height {
+			return
+		}
+
+		for x := range g.width {
+			if !yield(geom.Vec{X: x, Y: y}, g.cells[y*g.width+x]) {
+				return
+			}
+		}
+	}
+}
+
+// Column iterates over the cells of column x from top to bottom.
+func (g Grid[T]) Column(x int) iter.Seq2[geom.Vec, T] {
+	return func(yield func(geom.Vec, T) bool) {
+		if x < 0 || x >= g.width {
+			return
+		}
+
+		for y := range g.height {
+			if !yield(geom.Vec{X: x, Y: y}, g.cells[y*g.width+x]) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 iterates over the orthogonal neighbours of p which lie within the grid.
+func (g Grid[T]) Neighbors4(p geom.Vec) iter.Seq2[geom.Vec, T] {
+	return g.neighbors(p, geom.Directions)
+}
+
+// Neighbors8 iterates over the orthogonal and diagonal neighbours of p which lie within the grid.
+func (g Grid[T]) Neighbors8(p geom.Vec) iter.Seq2[geom.Vec, T] {
+	return g.neighbors(p, geom.Directions8)
+}
+
+func (g Grid[T]) neighbors(p geom.Vec, dirs []geom.Vec) iter.Seq2[geom.Vec, T] {
+	return func(yield func(geom.Vec, T) bool) {
+		for _, dir := range dirs {
+			n := p.Add(dir)
+			if !g.InBounds(n) {
+				continue
+			}
+
+			if !yield(n, g.cells[n.Y*g.width+n.X]) {
+				return
+			}
+		}
+	}
+}
+
+// FindFunc returns the position of the first cell (row by row) matching f.
+func (g Grid[T]) FindFunc(f func(T) bool) (geom.Vec, bool) {
+	for p, v := range g.All() {
+		if f(v) {
+			return p, true
+		}
+	}
+
+	return geom.Vec{}, false
+}
+
+// Find returns the position of the first cell (row by row) with the value v.
+func Find[T comparable](g Grid[T], v T) (geom.Vec, bool) {
+	return g.FindFunc(func(t T) bool {
+		return t == v
+	})
+}
+
+// Clone returns a deep copy of the grid.
+func (g Grid[T]) Clone() Grid[T] {
+	c := g
+	c.cells = append([]T(nil), g.cells...)
+	return c
+}
+
+// Transpose returns a new grid mirrored along the main diagonal.
+func (g Grid[T]) Transpose() Grid[T] {
+	t := New[T](g.height, g.width)
+	for p, v := range g.All() {
+		t.cells[p.X*t.width+p.Y] = v
+	}
+	return t
+}
+
+// Rotate returns a new grid rotated clockwise by 90 degrees.
+func (g Grid[T]) Rotate() Grid[T] {
+	r := New[T](g.height, g.width)
+	for p, v := range g.All() {
+		r.cells[p.X*r.width+(g.height-1-p.Y)] = v
+	}
+	return r
+}
+
+// String renders the grid row by row. Bytes and runes are written as characters, all other values
+// are formatted with fmt.
+func (g Grid[T]) String() string {
+	sb := strings.Builder{}
+
+	for i, v := range g.cells {
+		if i > 0 && i%g.width == 0 {
+			sb.WriteByte('\n')
+		}
+
+		switch t := any(v).(type) {
+		case byte:
+			sb.WriteByte(t)
+		case rune:
+			sb.WriteRune(t)
+		default:
+			fmt.Fprint(&sb, v)
+		}
+	}
+
+	return sb.String()
+}
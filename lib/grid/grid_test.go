@@ -0,0 +1,172 @@
+package grid
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/noxer/aoc/lib/geom"
+)
+
+// fromRows builds a byte grid from rows of equal length.
+func fromRows(rows ...string) Grid[byte] {
+	g := New[byte](len(rows[0]), len(rows))
+	for y, row := range rows {
+		for x := range len(row) {
+			g.Set(geom.Vec{X: x, Y: y}, row[x])
+		}
+	}
+	return g
+}
+
+func TestOutOfBounds(t *testing.T) {
+	g := NewFilled(3, 2, 7)
+
+	for _, p := range []geom.Vec{{X: -1}, {Y: -1}, {X: 3}, {Y: 2}, {X: 3, Y: 2}} {
+		if g.InBounds(p) {
+			t.Errorf("InBounds(%v) = true", p)
+		}
+		if v, ok := g.Get(p); ok || v != 0 {
+			t.Errorf("Get(%v) = %d, %t, want 0, false", p, v, ok)
+		}
+		if v := g.At(p); v != 0 {
+			t.Errorf("At(%v) = %d, want the zero value", p, v)
+		}
+		if g.Set(p, 1) {
+			t.Errorf("Set(%v) = true", p)
+		}
+	}
+
+	// Set outside must not wrap into the next row
+	for p, v := range g.All() {
+		if v != 7 {
+			t.Errorf("cell %v = %d after setting cells out of bounds", p, v)
+		}
+	}
+
+	if !g.Set(geom.Vec{X: 2, Y: 1}, 9) || g.At(geom.Vec{X: 2, Y: 1}) != 9 {
+		t.Errorf("Set inside the grid didn't change the cell")
+	}
+}
+
+func TestTransposeRotate(t *testing.T) {
+	g := fromRows(
+		"abc",
+		"def",
+	)
+
+	if got, want := g.Transpose().String(), "ad\nbe\ncf"; got != want {
+		t.Errorf("Transpose() =\n%s\nwant\n%s", got, want)
+	}
+	if got, want := g.Rotate().String(), "da\neb\nfc"; got != want {
+		t.Errorf("Rotate() =\n%s\nwant\n%s", got, want)
+	}
+	if got := g.Rotate().Rotate().Rotate().Rotate().String(); got != g.String() {
+		t.Errorf("four rotations =\n%s\nwant\n%s", got, g)
+	}
+	if g.Transpose().Width() != 2 || g.Transpose().Height() != 3 {
+		t.Errorf("Transpose() has size %v", g.Transpose().Size())
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := fromRows(
+		"abc",
+		"def",
+		"ghi",
+	)
+
+	collect := func(seq func(func(geom.Vec, byte) bool)) string {
+		var b []byte
+		for _, v := range seq {
+			b = append(b, v)
+		}
+		slices.Sort(b)
+		return string(b)
+	}
+
+	tests := []struct {
+		pos    geom.Vec
+		n4, n8 string
+	}{
+		{geom.Vec{X: 1, Y: 1}, "bdfh", "abcdfghi"},
+		{geom.Vec{X: 0, Y: 0}, "bd", "bde"},
+		{geom.Vec{X: 2, Y: 2}, "fh", "efh"},
+		{geom.Vec{X: 1, Y: 0}, "ace", "acdef"},
+		{geom.Vec{X: 0, Y: 2}, "dh", "deh"},
+	}
+
+	for _, tt := range tests {
+		if got := collect(g.Neighbors4(tt.pos)); got != tt.n4 {
+			t.Errorf("Neighbors4(%v) = %q, want %q", tt.pos, got, tt.n4)
+		}
+		if got := collect(g.Neighbors8(tt.pos)); got != tt.n8 {
+			t.Errorf("Neighbors8(%v) = %q, want %q", tt.pos, got, tt.n8)
+		}
+	}
+}
+
+func TestRowColumn(t *testing.T) {
+	g := fromRows(
+		"abc",
+		"def",
+	)
+
+	var row, col []byte
+	for _, v := range g.Row(1) {
+		row = append(row, v)
+	}
+	for _, v := range g.Column(2) {
+		col = append(col, v)
+	}
+
+	if string(row) != "def" || string(col) != "cf" {
+		t.Errorf("Row(1) = %q, Column(2) = %q", row, col)
+	}
+	for range g.Row(2) {
+		t.Errorf("Row(2) yields cells outside the grid")
+	}
+}
+
+func TestRead(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "map.txt")
+	if err := os.WriteFile(name, []byte("#..\n#\n.S.#\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := Read(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ragged lines are padded with spaces, trailing empty lines are dropped
+	if got, want := g.String(), "#.. \n#   \n.S.#"; got != want {
+		t.Errorf("Read() =\n%q\nwant\n%q", got, want)
+	}
+	if p, ok := Find(g, 'S'); !ok || p != (geom.Vec{X: 1, Y: 2}) {
+		t.Errorf("Find(S) = %v, %t", p, ok)
+	}
+	if _, ok := Find(g, 'E'); ok {
+		t.Errorf("Find(E) found a cell")
+	}
+
+	c := g.Clone()
+	c.Set(geom.Vec{}, 'x')
+	if g.At(geom.Vec{}) != '#' {
+		t.Errorf("changing the clone changed the grid")
+	}
+}
+
+func TestString(t *testing.T) {
+	ints := New[int](2, 2)
+	ints.Set(geom.Vec{X: 1, Y: 1}, 12)
+	if got, want := ints.String(), "00\n012"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	runes := NewFilled(2, 1, 'é')
+	if got, want := runes.String(), "éé"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package intervals
+
+import "strings"
+
+// Box is the product of one interval per dimension. The operations return new boxes and leave
+// their operands untouched.
+type Box []Interval
+
+// Empty reports whether the box contains no points, i.e. one of its intervals is empty.
+func (b Box) Empty() bool {
+	for _, iv := range b {
+		if iv.Empty() {
+			return true
+		}
+	}
+	return false
+}
+
+// Volume returns the number of integer points in the box.
+func (b Box) Volume() int {
+	v := 1
+	for _, iv := range b {
+		v *= iv.Len()
+	}
+	return v
+}
+
+// Contains reports whether the point with one coordinate per dimension lies in the box.
+func (b Box) Contains(p ...int) bool {
+	for i, iv := range b {
+		if !iv.Contains(p[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns the overlap of two boxes with the same number of dimensions.
+func (b Box) Intersect(o Box) Box {
+	res := make(Box, len(b))
+	for i := range b {
+		res[i] = b[i].Intersect(o[i])
+	}
+	return res
+}
+
+func (b Box) Overlaps(o Box) bool {
+	for i := range b {
+		if !b[i].Overlaps(o[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Split cuts the box along the dimension into the points whose coordinate is below at and the
+// rest. Either part may be empty.
+func (b Box) Split(dim, at int) (below, above Box) {
+	below, above = b.With(dim, Interval{}), b.With(dim, Interval{})
+	below[dim], above[dim] = b[dim].Split(at)
+	return below, above
+}
+
+// SplitFunc cuts the box along the dimension into the points for which the predicate holds and
+// the rest. The predicate must be monotonic along the dimension (e.g. x < 5 or x > 7), so both
+// parts are boxes again.
+func (b Box) SplitFunc(dim int, pred func(x int) bool) (match, rest Box) {
+	iv := b[dim]
+	if iv.Empty() {
+		return b.With(dim, iv), b.With(dim, iv)
+	}
+
+	// find the boundary with a binary search over the interval
+	first := pred(iv.Lo)
+	lo, hi := iv.Lo, iv.Hi
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if pred(mid) == first {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	below, above := b.Split(dim, lo)
+	if first {
+		return below, above
+	}
+	return above, below
+}
+
+// With returns a copy of the box with the interval of the dimension replaced.
+func (b Box) With(dim int, iv Interval) Box {
+	res := make(Box, len(b))
+	copy(res, b)
+	res[dim] = iv
+	return res
+}
+
+// Difference returns disjoint boxes covering the points of b which aren't in o.
+func (b Box) Difference(o Box) []Box {
+	if !b.Overlaps(o) {
+		if b.Empty() {
+			return nil
+		}
+		return []Box{b}
+	}
+
+	// peel off the parts outside of o one dimension at a time
+	var res []Box
+	rest := b
+	for dim := range b {
+		below, inside := rest.Split(dim, o[dim].Lo)
+		inside, above := inside.Split(dim, o[dim].Hi)
+		if !below.Empty() {
+			res = append(res, below)
+		}
+		if !above.Empty() {
+			res = append(res, above)
+		}
+		rest = inside
+	}
+
+	return res
+}
+
+func (b Box) String() string {
+	parts := make([]string, len(b))
+	for i, iv := range b {
+		parts[i] = iv.String()
+	}
+	return strings.Join(parts, " x ")
+}
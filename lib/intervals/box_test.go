@@ -0,0 +1,131 @@
+package intervals
+
+import (
+	"testing"
+)
+
+// decodeBox turns the bytes into a box with three dimensions and bounds in [-4, 20).
+func decodeBox(data []byte) Box {
+	var raw [6]byte
+	copy(raw[:], data)
+	return Box(decodeIntervals(raw[:]))
+}
+
+// eachPoint calls f for every point of the domain in three dimensions.
+func eachPoint(f func(x, y, z int)) {
+	for x := domain.Lo; x < domain.Hi; x++ {
+		for y := domain.Lo; y < domain.Hi; y++ {
+			for z := domain.Lo; z < domain.Hi; z++ {
+				f(x, y, z)
+			}
+		}
+	}
+}
+
+// checkPieces verifies that the pieces are disjoint, non-empty and cover exactly the points for
+// which want is true.
+func checkPieces(t *testing.T, name string, pieces []Box, want func(x, y, z int) bool) {
+	t.Helper()
+
+	volume := 0
+	for i, p := range pieces {
+		if p.Empty() {
+			t.Errorf("%s: piece %s is empty", name, p)
+		}
+		for _, q := range pieces[:i] {
+			if p.Overlaps(q) {
+				t.Errorf("%s: pieces %s and %s overlap", name, p, q)
+			}
+		}
+		volume += p.Volume()
+	}
+
+	n := 0
+	eachPoint(func(x, y, z int) {
+		covered := 0
+		for _, p := range pieces {
+			if p.Contains(x, y, z) {
+				covered++
+			}
+		}
+
+		if w := want(x, y, z); w {
+			n++
+			if covered != 1 {
+				t.Fatalf("%s: (%d,%d,%d) is covered by %d pieces of %v, want 1", name, x, y, z, covered, pieces)
+			}
+		} else if covered != 0 {
+			t.Fatalf("%s: (%d,%d,%d) is covered by %v, but shouldn't be", name, x, y, z, pieces)
+		}
+	})
+
+	if volume != n {
+		t.Errorf("%s: pieces have a volume of %d, want %d", name, volume, n)
+	}
+}
+
+func FuzzBoxDifference(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 10, 0, 10}, []byte{2, 5, 2, 5, 2, 5})
+	f.Add([]byte{0, 10, 0, 10, 0, 10}, []byte{5, 15, 5, 15, 5, 15})
+	f.Add([]byte{0, 10, 0, 10, 0, 10}, []byte{0, 10, 0, 10, 0, 10})
+	f.Add([]byte{0, 10, 0, 10, 0, 10}, []byte{12, 15, 0, 10, 0, 10})
+	f.Add([]byte{0, 10, 5, 3, 0, 10}, []byte{2, 5, 2, 5, 2, 5})
+
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		ba, bb := decodeBox(a), decodeBox(b)
+
+		checkPieces(t, "difference", ba.Difference(bb), func(x, y, z int) bool {
+			return ba.Contains(x, y, z) && !bb.Contains(x, y, z)
+		})
+
+		if v := ba.Intersect(bb).Volume() + volumeOf(ba.Difference(bb)); v != ba.Volume() {
+			t.Errorf("%s: intersection and difference with %s have a volume of %d, want %d", ba, bb, v, ba.Volume())
+		}
+	})
+}
+
+func FuzzBoxSplitFunc(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 10, 0, 10}, uint8(0), int8(5), false)
+	f.Add([]byte{0, 10, 0, 10, 0, 10}, uint8(1), int8(-3), true)
+	f.Add([]byte{0, 10, 0, 10, 0, 10}, uint8(2), int8(30), false)
+	f.Add([]byte{10, 0, 0, 10, 0, 10}, uint8(0), int8(5), true)
+
+	f.Fuzz(func(t *testing.T, data []byte, dim uint8, at int8, greater bool) {
+		b := decodeBox(data)
+		d := int(dim % 3)
+
+		// both directions are monotonic
+		pred := func(x int) bool { return x < int(at) }
+		if greater {
+			pred = func(x int) bool { return x >= int(at) }
+		}
+
+		match, rest := b.SplitFunc(d, pred)
+		if match.Volume()+rest.Volume() != b.Volume() {
+			t.Errorf("%s split = %s, %s, volumes don't add up", b, match, rest)
+		}
+
+		coord := func(x, y, z int) int { return [3]int{x, y, z}[d] }
+		var pieces []Box
+		for _, p := range []Box{match, rest} {
+			if !p.Empty() {
+				pieces = append(pieces, p)
+			}
+		}
+		checkPieces(t, "split", pieces, func(x, y, z int) bool { return b.Contains(x, y, z) })
+
+		eachPoint(func(x, y, z int) {
+			if match.Contains(x, y, z) && !pred(coord(x, y, z)) || rest.Contains(x, y, z) && pred(coord(x, y, z)) {
+				t.Fatalf("%s split = %s, %s puts (%d,%d,%d) on the wrong side", b, match, rest, x, y, z)
+			}
+		})
+	})
+}
+
+func volumeOf(boxes []Box) int {
+	v := 0
+	for _, b := range boxes {
+		v += b.Volume()
+	}
+	return v
+}
@@ -0,0 +1,201 @@
+// Package intervals implements integer intervals, sets of intervals and N-dimensional boxes.
+// Intervals are half-open internally, Closed converts from the inclusive bounds most puzzles use.
+package intervals
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Interval is the half-open range [Lo, Hi) of integers. It is empty if Hi <= Lo.
+type Interval struct {
+	Lo, Hi int
+}
+
+// Closed returns the interval [a, b] including both ends.
+func Closed(a, b int) Interval {
+	return Interval{Lo: a, Hi: b + 1}
+}
+
+// Empty reports whether the interval contains no integers.
+func (i Interval) Empty() bool {
+	return i.Hi <= i.Lo
+}
+
+// Len returns the number of integers in the interval.
+func (i Interval) Len() int {
+	if i.Empty() {
+		return 0
+	}
+	return i.Hi - i.Lo
+}
+
+// Last returns the largest integer in the interval, the inclusive upper bound.
+func (i Interval) Last() int {
+	return i.Hi - 1
+}
+
+func (i Interval) Contains(x int) bool {
+	return i.Lo <= x && x < i.Hi
+}
+
+// Intersect returns the integers in both intervals, the result may be empty.
+func (i Interval) Intersect(o Interval) Interval {
+	return Interval{Lo: max(i.Lo, o.Lo), Hi: min(i.Hi, o.Hi)}
+}
+
+func (i Interval) Overlaps(o Interval) bool {
+	return !i.Intersect(o).Empty()
+}
+
+// Split cuts the interval into the integers below at and the rest. Either part may be empty.
+func (i Interval) Split(at int) (below, above Interval) {
+	at = min(max(at, i.Lo), max(i.Hi, i.Lo))
+	return Interval{Lo: i.Lo, Hi: at}, Interval{Lo: at, Hi: i.Hi}
+}
+
+// Difference returns the parts of i not in o, at most two intervals.
+func (i Interval) Difference(o Interval) []Interval {
+	if !i.Overlaps(o) {
+		if i.Empty() {
+			return nil
+		}
+		return []Interval{i}
+	}
+
+	var res []Interval
+	if i.Lo < o.Lo {
+		res = append(res, Interval{Lo: i.Lo, Hi: o.Lo})
+	}
+	if o.Hi < i.Hi {
+		res = append(res, Interval{Lo: o.Hi, Hi: i.Hi})
+	}
+	return res
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%d, %d)", i.Lo, i.Hi)
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Merge sorts the intervals and joins overlapping and adjacent ones. Empty intervals are dropped.
+// The argument is left untouched.
+func Merge(ivs []Interval) []Interval {
+	sorted := make([]Interval, 0, len(ivs))
+	for _, iv := range ivs {
+		if !iv.Empty() {
+			sorted = append(sorted, iv)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b Interval) int {
+		return a.Lo - b.Lo
+	})
+
+	merged := sorted[:0]
+	for _, iv := range sorted {
+		if n := len(merged); n > 0 && iv.Lo <= merged[n-1].Hi {
+			merged[n-1].Hi = max(merged[n-1].Hi, iv.Hi)
+			continue
+		}
+		merged = append(merged, iv)
+	}
+
+	return merged
+}
+
+// Set is a union of intervals, kept as sorted disjoint intervals. The zero value is the empty set.
+type Set struct {
+	ivs []Interval
+}
+
+// NewSet returns the union of the intervals.
+func NewSet(ivs ...Interval) Set {
+	return Set{ivs: Merge(ivs)}
+}
+
+// Intervals returns the disjoint intervals of the set in ascending order.
+func (s Set) Intervals() []Interval {
+	return slices.Clone(s.ivs)
+}
+
+func (s Set) Empty() bool {
+	return len(s.ivs) == 0
+}
+
+// Len returns the number of integers in the set.
+func (s Set) Len() int {
+	n := 0
+	for _, iv := range s.ivs {
+		n += iv.Len()
+	}
+	return n
+}
+
+func (s Set) Contains(x int) bool {
+	i, found := slices.BinarySearchFunc(s.ivs, x, func(iv Interval, x int) int {
+		switch {
+		case iv.Hi <= x:
+			return -1
+		case iv.Lo > x:
+			return +1
+		}
+		return 0
+	})
+	return found && s.ivs[i].Contains(x)
+}
+
+func (s Set) Union(o Set) Set {
+	return NewSet(append(slices.Clone(s.ivs), o.ivs...)...)
+}
+
+func (s Set) Intersect(o Set) Set {
+	var res []Interval
+	i, j := 0, 0
+	for i < len(s.ivs) && j < len(o.ivs) {
+		if iv := s.ivs[i].Intersect(o.ivs[j]); !iv.Empty() {
+			res = append(res, iv)
+		}
+
+		// advance the interval which ends first
+		if s.ivs[i].Hi < o.ivs[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return Set{ivs: res}
+}
+
+// Difference returns the integers in s which aren't in o.
+func (s Set) Difference(o Set) Set {
+	var res []Interval
+	j := 0
+	for _, iv := range s.ivs {
+		// skip the intervals of o ending before iv
+		for j < len(o.ivs) && o.ivs[j].Hi <= iv.Lo {
+			j++
+		}
+
+		rest := iv
+		for k := j; k < len(o.ivs) && o.ivs[k].Lo < rest.Hi; k++ {
+			if o.ivs[k].Lo > rest.Lo {
+				res = append(res, Interval{Lo: rest.Lo, Hi: o.ivs[k].Lo})
+			}
+			rest.Lo = max(rest.Lo, o.ivs[k].Hi)
+		}
+		if !rest.Empty() {
+			res = append(res, rest)
+		}
+	}
+	return Set{ivs: res}
+}
+
+func (s Set) String() string {
+	parts := make([]string, len(s.ivs))
+	for i, iv := range s.ivs {
+		parts[i] = iv.String()
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,108 @@
+package intervals
+
+import (
+	"testing"
+)
+
+// domain covers all integers the fuzzed intervals can contain, with a margin on both sides.
+var domain = Interval{Lo: -8, Hi: 24}
+
+// decodeIntervals turns pairs of bytes into intervals with bounds in [-4, 20). Reversed bounds
+// result in empty intervals.
+func decodeIntervals(data []byte) []Interval {
+	ivs := make([]Interval, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		ivs = append(ivs, Interval{Lo: int(data[i]%24) - 4, Hi: int(data[i+1]%24) - 4})
+	}
+	return ivs
+}
+
+// points is the brute force version of a set, one bool per integer of the domain.
+type points map[int]bool
+
+func pointsOf(ivs ...Interval) points {
+	p := points{}
+	for _, iv := range ivs {
+		for x := iv.Lo; x < iv.Hi; x++ {
+			p[x] = true
+		}
+	}
+	return p
+}
+
+// check compares the set with the expected points and verifies that its intervals are sorted,
+// disjoint, not adjacent and not empty.
+func check(t *testing.T, name string, s Set, want func(x int) bool) {
+	t.Helper()
+
+	ivs := s.Intervals()
+	for i, iv := range ivs {
+		if iv.Empty() {
+			t.Errorf("%s = %s contains an empty interval", name, s)
+		}
+		if i > 0 && ivs[i-1].Hi >= iv.Lo {
+			t.Errorf("%s = %s isn't sorted and merged", name, s)
+		}
+	}
+
+	n := 0
+	for x := domain.Lo; x < domain.Hi; x++ {
+		if s.Contains(x) != want(x) {
+			t.Errorf("%s = %s, Contains(%d) = %t", name, s, x, s.Contains(x))
+		}
+		if want(x) {
+			n++
+		}
+	}
+	if s.Len() != n {
+		t.Errorf("%s = %s, Len() = %d, want %d", name, s, s.Len(), n)
+	}
+}
+
+func FuzzSet(f *testing.F) {
+	f.Add([]byte{4, 10, 8, 14}, []byte{0, 6, 12, 20})
+	f.Add([]byte{4, 10, 10, 14}, []byte{10, 10})
+	f.Add([]byte{4, 8, 12, 16}, []byte{8, 12, 3, 1})
+	f.Add([]byte{}, []byte{0, 23})
+
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		ivsA, ivsB := decodeIntervals(a), decodeIntervals(b)
+		sa, sb := NewSet(ivsA...), NewSet(ivsB...)
+		pa, pb := pointsOf(ivsA...), pointsOf(ivsB...)
+
+		check(t, "a", sa, func(x int) bool { return pa[x] })
+		check(t, "a ∪ b", sa.Union(sb), func(x int) bool { return pa[x] || pb[x] })
+		check(t, "a ∩ b", sa.Intersect(sb), func(x int) bool { return pa[x] && pb[x] })
+		check(t, "a \\ b", sa.Difference(sb), func(x int) bool { return pa[x] && !pb[x] })
+	})
+}
+
+func FuzzIntervalDifference(f *testing.F) {
+	f.Add([]byte{4, 16, 8, 10})
+	f.Add([]byte{4, 16, 0, 10})
+	f.Add([]byte{4, 16, 0, 20})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ivs := decodeIntervals(data)
+		if len(ivs) < 2 {
+			return
+		}
+		a, b := ivs[0], ivs[1]
+
+		diff := a.Difference(b)
+		if len(diff) > 2 {
+			t.Errorf("%s \\ %s = %v has more than two parts", a, b, diff)
+		}
+		check(t, "difference", NewSet(diff...), func(x int) bool { return a.Contains(x) && !b.Contains(x) })
+
+		below, above := a.Split(b.Lo)
+		if below.Len()+above.Len() != a.Len() {
+			t.Errorf("%s split at %d = %s, %s", a, b.Lo, below, above)
+		}
+		for x := domain.Lo; x < domain.Hi; x++ {
+			if below.Contains(x) && (x >= b.Lo || above.Contains(x)) || above.Contains(x) && x < b.Lo {
+				t.Errorf("%s split at %d = %s, %s", a, b.Lo, below, above)
+			}
+		}
+	})
+}
@@ -0,0 +1,313 @@
+// Package mathx contains exact integer number theory: greatest common divisors, modular inverses,
+// the Chinese remainder theorem, solving linear systems and extrapolating polynomials. The int
+// functions fall back to math/big where intermediate results would overflow, the *Big variants
+// work on arbitrary sizes.
+package mathx
+
+import (
+	"math"
+	"math/big"
+)
+
+// Abs returns the absolute value of n.
+func Abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Mod returns n modulo m in the range [0, m) for positive m.
+func Mod(n, m int) int {
+	r := n % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// GCD returns the greatest common divisor of the numbers, it is always non-negative. GCD() is 0.
+func GCD(ns ...int) int {
+	g := 0
+	for _, n := range ns {
+		a, b := g, Abs(n)
+		for b != 0 {
+			a, b = b, a%b
+		}
+		g = a
+	}
+	return g
+}
+
+// LCM returns the least common multiple of the numbers, LCM() is 1. It panics if the result
+// doesn't fit into an int, use LCMBig in that case.
+func LCM(ns ...int) int {
+	l := 1
+	for _, n := range ns {
+		if n == 0 {
+			return 0
+		}
+
+		var ok bool
+		if l, ok = mul(l/GCD(l, n), Abs(n)); !ok {
+			panic("mathx: LCM overflows int")
+		}
+	}
+	return l
+}
+
+// LCMBig returns the least common multiple of the numbers.
+func LCMBig(ns ...int) *big.Int {
+	l := big.NewInt(1)
+	g := new(big.Int)
+	for _, n := range ns {
+		if n == 0 {
+			return new(big.Int)
+		}
+
+		b := big.NewInt(int64(Abs(n)))
+		g.GCD(nil, nil, l, b)
+		l.Mul(l.Div(l, g), b)
+	}
+	return l
+}
+
+// ExtGCD returns g = gcd(a, b) and the Bézout coefficients x and y with a*x + b*y = g.
+func ExtGCD(a, b int) (g, x, y int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// ModInverse returns x with a*x = 1 (mod m). ok is false if a and m aren't coprime.
+func ModInverse(a, m int) (x int, ok bool) {
+	g, x, _ := ExtGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// CRT solves the system x = residues[i] (mod moduli[i]). The moduli must be positive but don't
+// have to be coprime. It returns the smallest non-negative solution r and the combined modulus
+// m = lcm(moduli), every r + k*m is a solution too. ok is false if the system has no solution.
+// It panics if m doesn't fit into an int, use CRTBig in that case.
+func CRT(residues, moduli []int) (r, m int, ok bool) {
+	if len(residues) != len(moduli) {
+		panic("mathx: CRT needs one modulus per residue")
+	}
+
+	r, m = 0, 1
+	for i, mi := range moduli {
+		ri := Mod(residues[i], mi)
+
+		g, p, _ := ExtGCD(m, mi)
+		if (ri-r)%g != 0 {
+			return 0, 0, false
+		}
+
+		// solve r + m*k = ri (mod mi) for k, m/g is invertible modulo mi/g with inverse p
+		step := mi / g
+		k := mulMod(Mod((ri-r)/g, step), Mod(p, step), step)
+
+		next, ok := mul(m, step)
+		if !ok {
+			panic("mathx: CRT modulus overflows int")
+		}
+		// r < m and k < step, so r + m*k < next
+		r, m = r+m*k, next
+	}
+
+	return r, m, true
+}
+
+// CRTBig works like CRT on arbitrarily large numbers.
+func CRTBig(residues, moduli []*big.Int) (r, m *big.Int, ok bool) {
+	if len(residues) != len(moduli) {
+		panic("mathx: CRT needs one modulus per residue")
+	}
+
+	r, m = new(big.Int), big.NewInt(1)
+	g, p, diff, step, k := new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	for i, mi := range moduli {
+		ri := new(big.Int).Mod(residues[i], mi)
+
+		g.GCD(p, nil, m, mi)
+		diff.Sub(ri, r)
+		if new(big.Int).Mod(diff, g).Sign() != 0 {
+			return nil, nil, false
+		}
+
+		step.Div(mi, g)
+		k.Div(diff, g)
+		k.Mul(k, p)
+		k.Mod(k, step)
+
+		r.Add(r, k.Mul(k, m))
+		m.Mul(m, step)
+	}
+
+	return r, m, true
+}
+
+// Cramer solves the system
+//
+//	a*x + b*y = e
+//	c*x + d*y = f
+//
+// with Cramer's rule. ok is false if there is no unique integer solution.
+func Cramer(a, b, c, d, e, f int) (x, y int, ok bool) {
+	det, ok1 := det2(a, b, c, d)
+	detX, ok2 := det2(e, b, f, d)
+	detY, ok3 := det2(a, e, c, f)
+	if !ok1 || !ok2 || !ok3 {
+		bx, by, ok := CramerBig(big.NewInt(int64(a)), big.NewInt(int64(b)), big.NewInt(int64(c)),
+			big.NewInt(int64(d)), big.NewInt(int64(e)), big.NewInt(int64(f)))
+		if !ok || !bx.IsInt64() || !by.IsInt64() {
+			return 0, 0, false
+		}
+		return int(bx.Int64()), int(by.Int64()), true
+	}
+
+	if det == 0 || detX%det != 0 || detY%det != 0 {
+		return 0, 0, false
+	}
+
+	return detX / det, detY / det, true
+}
+
+// CramerBig works like Cramer on arbitrarily large numbers.
+func CramerBig(a, b, c, d, e, f *big.Int) (x, y *big.Int, ok bool) {
+	det := bigDet2(a, b, c, d)
+	if det.Sign() == 0 {
+		return nil, nil, false
+	}
+
+	x, rx := new(big.Int).QuoRem(bigDet2(e, b, f, d), det, new(big.Int))
+	y, ry := new(big.Int).QuoRem(bigDet2(a, e, c, f), det, new(big.Int))
+	if rx.Sign() != 0 || ry.Sign() != 0 {
+		return nil, nil, false
+	}
+
+	return x, y, true
+}
+
+// CramerRat works like CramerBig but returns the rational solution. ok is false if the
+// determinant is zero.
+func CramerRat(a, b, c, d, e, f *big.Int) (x, y *big.Rat, ok bool) {
+	det := bigDet2(a, b, c, d)
+	if det.Sign() == 0 {
+		return nil, nil, false
+	}
+
+	x = new(big.Rat).SetFrac(bigDet2(e, b, f, d), det)
+	y = new(big.Rat).SetFrac(bigDet2(a, e, c, f), det)
+
+	return x, y, true
+}
+
+// SolveRat solves the linear system a*x = b with Gaussian elimination. The arguments are left
+// untouched. ok is false if the matrix is singular.
+func SolveRat(a [][]*big.Rat, b []*big.Rat) (x []*big.Rat, ok bool) {
+	n := len(b)
+
+	// augmented copy of the system
+	m := make([][]*big.Rat, n)
+	for i := range m {
+		if len(a[i]) != n {
+			panic("mathx: SolveRat needs a square matrix")
+		}
+		m[i] = make([]*big.Rat, n+1)
+		for j := range n {
+			m[i][j] = new(big.Rat).Set(a[i][j])
+		}
+		m[i][n] = new(big.Rat).Set(b[i])
+	}
+
+	tmp := new(big.Rat)
+	for col := range n {
+		pivot := -1
+		for row := col; row < n; row++ {
+			if m[row][col].Sign() != 0 {
+				pivot = row
+				break
+			}
+		}
+		if pivot < 0 {
+			return nil, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for row := range n {
+			if row == col || m[row][col].Sign() == 0 {
+				continue
+			}
+
+			factor := new(big.Rat).Quo(m[row][col], m[col][col])
+			for j := col; j <= n; j++ {
+				m[row][j].Sub(m[row][j], tmp.Mul(factor, m[col][j]))
+			}
+		}
+	}
+
+	x = make([]*big.Rat, n)
+	for i := range n {
+		x[i] = new(big.Rat).Quo(m[i][n], m[i][i])
+	}
+
+	return x, true
+}
+
+func det2(a, b, c, d int) (int, bool) {
+	ad, ok1 := mul(a, d)
+	bc, ok2 := mul(b, c)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+
+	det := ad - bc
+	// the subtraction overflows if the signs of the operands differ and the result has the wrong sign
+	if (ad >= 0) != (bc >= 0) && (det >= 0) != (ad >= 0) {
+		return 0, false
+	}
+	return det, true
+}
+
+func bigDet2(a, b, c, d *big.Int) *big.Int {
+	ad := new(big.Int).Mul(a, d)
+	return ad.Sub(ad, new(big.Int).Mul(b, c))
+}
+
+// mul returns a*b and whether the product fits into an int.
+func mul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	p := a * b
+	if p/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return p, true
+}
+
+// mulMod returns a*b mod m for 0 <= a, b < m without overflowing.
+func mulMod(a, b, m int) int {
+	if p, ok := mul(a, b); ok {
+		return p % m
+	}
+
+	p := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(b)))
+	return int(p.Mod(p, big.NewInt(int64(m))).Int64())
+}
@@ -0,0 +1,266 @@
+package mathx
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestGCDLCM(t *testing.T) {
+	if g := GCD(12, -18, 30); g != 6 {
+		t.Errorf("GCD(12, -18, 30) = %d, want 6", g)
+	}
+	if g := GCD(); g != 0 {
+		t.Errorf("GCD() = %d, want 0", g)
+	}
+	if l := LCM(4, 6, -10); l != 60 {
+		t.Errorf("LCM(4, 6, -10) = %d, want 60", l)
+	}
+	if l := LCM(3, 0); l != 0 {
+		t.Errorf("LCM(3, 0) = %d, want 0", l)
+	}
+	if l := LCMBig(1<<40, 3<<40, 5); l.String() != "16492674416640" {
+		t.Errorf("LCMBig() = %s", l)
+	}
+	if m := Mod(-7, 5); m != 3 {
+		t.Errorf("Mod(-7, 5) = %d, want 3", m)
+	}
+}
+
+func TestLCMOverflow(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("LCM didn't panic on overflow")
+		}
+	}()
+	LCM(math.MaxInt32, math.MaxInt32-1, math.MaxInt32-2)
+}
+
+func TestExtGCD(t *testing.T) {
+	tests := []struct {
+		a, b, g int
+	}{
+		{240, 46, 2},
+		{46, 240, 2},
+		{17, 5, 1},
+		{-4, 6, 2},
+		{4, -6, 2},
+		{0, 5, 5},
+		{5, 0, 5},
+		{0, 0, 0},
+		{math.MaxInt, math.MaxInt - 1, 1},
+	}
+
+	for _, tt := range tests {
+		g, x, y := ExtGCD(tt.a, tt.b)
+		if g != tt.g {
+			t.Errorf("ExtGCD(%d, %d) gcd = %d, want %d", tt.a, tt.b, g, tt.g)
+		}
+
+		// check the Bézout identity without overflowing
+		sum := new(big.Int).Mul(big.NewInt(int64(tt.a)), big.NewInt(int64(x)))
+		sum.Add(sum, new(big.Int).Mul(big.NewInt(int64(tt.b)), big.NewInt(int64(y))))
+		if sum.Cmp(big.NewInt(int64(g))) != 0 {
+			t.Errorf("ExtGCD(%d, %d) = %d, %d, %d, but a*x + b*y = %s", tt.a, tt.b, g, x, y, sum)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	tests := []struct {
+		a, m, want int
+		ok         bool
+	}{
+		{3, 11, 4, true},
+		{10, 17, 12, true},
+		{-3, 11, 7, true},
+		{14, 11, 4, true},
+		{1, 2, 1, true},
+		{6, 9, 0, false},
+		{0, 7, 0, false},
+		{4, 8, 0, false},
+	}
+
+	for _, tt := range tests {
+		x, ok := ModInverse(tt.a, tt.m)
+		if x != tt.want || ok != tt.ok {
+			t.Errorf("ModInverse(%d, %d) = %d, %t, want %d, %t", tt.a, tt.m, x, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		name     string
+		residues []int
+		moduli   []int
+		r, m     int
+		ok       bool
+	}{
+		{"coprime", []int{2, 3, 2}, []int{3, 5, 7}, 23, 105, true},
+		{"negative residue", []int{-1, 0}, []int{5, 3}, 9, 15, true},
+		{"residue above modulus", []int{7, 11}, []int{5, 3}, 2, 15, true},
+		{"common factor", []int{3, 1}, []int{4, 6}, 7, 12, true},
+		{"same modulus", []int{2, 2}, []int{6, 6}, 2, 6, true},
+		{"inconsistent", []int{0, 1}, []int{4, 6}, 0, 0, false},
+		{"inconsistent same modulus", []int{1, 2}, []int{5, 5}, 0, 0, false},
+		{"empty", nil, nil, 0, 1, true},
+		// the product of the moduli fits, but not the intermediate products
+		{"large", []int{1, 2}, []int{2147483647, 2147483629}, 4355481199181591002, 4611685975477714963, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, m, ok := CRT(tt.residues, tt.moduli)
+			if r != tt.r || m != tt.m || ok != tt.ok {
+				t.Errorf("CRT() = %d, %d, %t, want %d, %d, %t", r, m, ok, tt.r, tt.m, tt.ok)
+			}
+
+			br, bm, ok := CRTBig(bigs(tt.residues...), bigs(tt.moduli...))
+			if ok != tt.ok || ok && (br.Cmp(big.NewInt(int64(tt.r))) != 0 || bm.Cmp(big.NewInt(int64(tt.m))) != 0) {
+				t.Errorf("CRTBig() = %s, %s, %t, want %d, %d, %t", br, bm, ok, tt.r, tt.m, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCRTOverflow(t *testing.T) {
+	// the product of the moduli is about 10^30
+	residues := []int{1, 2, 3, 4, 5}
+	moduli := []int{1000003, 1000033, 1000037, 1000039, 1000081}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("CRT didn't panic on overflow")
+			}
+		}()
+		CRT(residues, moduli)
+	}()
+
+	r, m, ok := CRTBig(bigs(residues...), bigs(moduli...))
+	if !ok {
+		t.Fatal("CRTBig() found no solution")
+	}
+	if r.Sign() < 0 || r.Cmp(m) >= 0 {
+		t.Errorf("CRTBig() = %s, not in [0, %s)", r, m)
+	}
+	for i, mi := range moduli {
+		if rem := new(big.Int).Mod(r, big.NewInt(int64(mi))); rem.Int64() != int64(residues[i]) {
+			t.Errorf("CRTBig() = %s, which is %s mod %d", r, rem, mi)
+		}
+	}
+}
+
+func TestCramer(t *testing.T) {
+	tests := []struct {
+		name             string
+		a, b, c, d, e, f int
+		x, y             int
+		ok               bool
+	}{
+		{"unique", 2, 1, 1, -1, 5, 1, 2, 1, true},
+		{"negative", 94, 22, 34, 67, 8400, 5400, 80, 40, true},
+		{"not integer", 2, 0, 0, 1, 1, 1, 0, 0, false},
+		{"singular", 1, 2, 2, 4, 3, 6, 0, 0, false},
+		// the determinant overflows, the solution doesn't
+		{"overflow", 1e10, 1, 1, 1e10, 3e10 - 7, 3 - 7e10, 3, -7, true},
+		{"overflow in a determinant", 1, 0, 0, 1e10, math.MaxInt, 1e10, math.MaxInt, 1, true},
+		// x = 2*MaxInt
+		{"solution overflows", 1, 1, 1, 2, math.MaxInt, 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, ok := Cramer(tt.a, tt.b, tt.c, tt.d, tt.e, tt.f)
+			if x != tt.x || y != tt.y || ok != tt.ok {
+				t.Errorf("Cramer() = %d, %d, %t, want %d, %d, %t", x, y, ok, tt.x, tt.y, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCramerRat(t *testing.T) {
+	x, y, ok := CramerRat(bigs(2)[0], bigs(1)[0], bigs(1)[0], bigs(3)[0], bigs(1)[0], bigs(2)[0])
+	if !ok || x.RatString() != "1/5" || y.RatString() != "3/5" {
+		t.Errorf("CramerRat() = %s, %s, %t, want 1/5, 3/5", x, y, ok)
+	}
+
+	if _, _, ok = CramerRat(bigs(1)[0], bigs(2)[0], bigs(2)[0], bigs(4)[0], bigs(1)[0], bigs(2)[0]); ok {
+		t.Error("CramerRat() solved a singular system")
+	}
+}
+
+func TestSolveRat(t *testing.T) {
+	huge := new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 100))
+
+	tests := []struct {
+		name string
+		a    [][]*big.Rat
+		b    []*big.Rat
+		want []string
+	}{
+		{
+			"integer",
+			[][]*big.Rat{rats(2, 1, -1), rats(-3, -1, 2), rats(-2, 1, 2)},
+			rats(8, -11, -3),
+			[]string{"2", "3", "-1"},
+		},
+		{
+			// the first pivot is zero, the rows have to be swapped
+			"pivot",
+			[][]*big.Rat{rats(0, 1), rats(2, 1)},
+			rats(1, 2),
+			[]string{"1/2", "1"},
+		},
+		{
+			"beyond int64",
+			[][]*big.Rat{{huge, rats(1)[0]}, rats(1, -1)},
+			[]*big.Rat{new(big.Rat).Add(huge, rats(1)[0]), rats(0)[0]},
+			[]string{"1", "1"},
+		},
+		{
+			"singular",
+			[][]*big.Rat{rats(1, 2), rats(2, 4)},
+			rats(3, 6),
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := fmt.Sprint(tt.a, tt.b)
+
+			x, ok := SolveRat(tt.a, tt.b)
+			if ok != (tt.want != nil) {
+				t.Fatalf("SolveRat() ok = %t", ok)
+			}
+			for i := range tt.want {
+				if x[i].RatString() != tt.want[i] {
+					t.Errorf("x[%d] = %s, want %s", i, x[i].RatString(), tt.want[i])
+				}
+			}
+
+			if after := fmt.Sprint(tt.a, tt.b); after != before {
+				t.Errorf("SolveRat() changed its arguments from %s to %s", before, after)
+			}
+		})
+	}
+}
+
+func bigs(ns ...int) []*big.Int {
+	out := make([]*big.Int, len(ns))
+	for i, n := range ns {
+		out[i] = big.NewInt(int64(n))
+	}
+	return out
+}
+
+func rats(ns ...int64) []*big.Rat {
+	out := make([]*big.Rat, len(ns))
+	for i, n := range ns {
+		out[i] = big.NewRat(n, 1)
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package mathx
+
+import "math/big"
+
+// Degree returns the degree of the polynomial of lowest degree through the samples taken at
+// equally spaced points, -1 if all samples are zero. A degree of len(samples)-1 means the samples
+// don't confirm a lower degree.
+func Degree(samples []int) int {
+	diffs := append([]int(nil), samples...)
+	for len(diffs) > 0 {
+		zero := true
+		for _, d := range diffs {
+			zero = zero && d == 0
+		}
+		if zero {
+			break
+		}
+
+		for i := range len(diffs) - 1 {
+			diffs[i] = diffs[i+1] - diffs[i]
+		}
+		diffs = diffs[:len(diffs)-1]
+	}
+
+	return len(samples) - len(diffs) - 1
+}
+
+// Extrapolate evaluates the polynomial of lowest degree through the samples taken at 0, 1, ...,
+// len(samples)-1 at x. It uses Newton's forward differences, so the result is exact for every
+// integer x.
+func Extrapolate(samples []int, x int) *big.Int {
+	diffs := make([]*big.Int, len(samples))
+	for i, s := range samples {
+		diffs[i] = big.NewInt(int64(s))
+	}
+
+	// sum of the leading differences times binomial(x, i)
+	sum := new(big.Int)
+	binom := big.NewInt(1)
+	for i := range samples {
+		sum.Add(sum, new(big.Int).Mul(diffs[0], binom))
+
+		for j := range len(diffs) - 1 {
+			diffs[j].Sub(diffs[j+1], diffs[j])
+		}
+		diffs = diffs[:len(diffs)-1]
+
+		binom.Mul(binom, big.NewInt(int64(x-i)))
+		binom.Quo(binom, big.NewInt(int64(i+1)))
+	}
+
+	return sum
+}
@@ -0,0 +1,71 @@
+package mathx
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDegree(t *testing.T) {
+	tests := []struct {
+		samples []int
+		want    int
+	}{
+		{nil, -1},
+		{[]int{0, 0, 0}, -1},
+		{[]int{5, 5, 5, 5}, 0},
+		{[]int{1, 3, 5, 7}, 1},
+		{[]int{0, 1, 4, 9, 16}, 2},
+		{[]int{0, 1, 8, 27, 64}, 3},
+		// three samples can't confirm anything below degree 2
+		{[]int{1, 2, 4}, 2},
+	}
+
+	for _, tt := range tests {
+		if got := Degree(tt.samples); got != tt.want {
+			t.Errorf("Degree(%v) = %d, want %d", tt.samples, got, tt.want)
+		}
+	}
+}
+
+func TestExtrapolate(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []int
+		x       int
+		want    string
+	}{
+		{"empty", nil, 10, "0"},
+		{"constant", []int{7, 7}, 100, "7"},
+		{"linear", []int{0, 3, 6, 9, 12, 15}, 6, "18"},
+		{"square", []int{0, 1, 4}, 10, "100"},
+		{"sample", []int{1, 3, 6, 10, 15, 21}, 3, "10"},
+		// 2023 day 9: extrapolating backwards
+		{"backwards", []int{10, 13, 16, 21, 30, 45}, -1, "5"},
+		{"cube at a negative x", []int{0, 1, 8, 27}, -3, "-27"},
+		// the result doesn't fit into an int
+		{"huge", []int{0, 1, 4}, 1 << 40, "1208925819614629174706176"},
+		// a quadratic sampled every 131 steps like in 2023 day 21
+		{"steps", []int{3944, 35082, 97230}, 202300, "634549784009844"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extrapolate(tt.samples, tt.x); got.String() != tt.want {
+				t.Errorf("Extrapolate(%v, %d) = %s, want %s", tt.samples, tt.x, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExtrapolateSamples leaves the samples untouched.
+func TestExtrapolateSamples(t *testing.T) {
+	samples := []int{1, 4, 9}
+	Extrapolate(samples, 5)
+
+	if samples[0] != 1 || samples[1] != 4 || samples[2] != 9 {
+		t.Errorf("Extrapolate changed the samples to %v", samples)
+	}
+	if got := Extrapolate(samples, 5); got.Cmp(big.NewInt(36)) != 0 {
+		t.Errorf("Extrapolate(%v, 5) = %s, want 36", samples, got)
+	}
+}
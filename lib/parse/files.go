@@ -1,4 +1,5 @@
-package utils
+// Package parse contains helpers for reading and parsing puzzle inputs.
+package parse
 
 import (
 	"bufio"
@@ -1,4 +1,4 @@
-package utils
+package parse
 
 import (
 	"fmt"
@@ -21,3 +21,16 @@ func ParseInts(str, sep string) []int {
 
 	return ints
 }
+
+// IntArg parses the optional argument at index i as an int, def is returned if it is missing.
+func IntArg(args []string, i, def int) (int, error) {
+	if len(args) <= i {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(args[i])
+	if err != nil {
+		return 0, fmt.Errorf("invalid argument %q: %w", args[i], err)
+	}
+	return n, nil
+}
@@ -0,0 +1,283 @@
+// Package search contains generic shortest path searches over implicit graphs. States are any
+// comparable type, the graph is described by a neighbour function and a cost function.
+package search
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/noxer/aoc/lib/collections"
+)
+
+// Neighbors returns the states reachable from s in a single step.
+type Neighbors[S comparable] func(s S) iter.Seq[S]
+
+// Cost returns the cost of the step from one state to a neighbouring state.
+type Cost[S comparable] func(from, to S) int
+
+// Heuristic estimates the remaining cost from s to the goal. It must never overestimate.
+type Heuristic[S comparable] func(s S) int
+
+// Goal reports whether s is a goal state. A nil goal explores the whole reachable graph.
+type Goal[S comparable] func(s S) bool
+
+// Result holds the distances and predecessors found by a search.
+type Result[S comparable] struct {
+	Dist  map[S]int
+	Prev  map[S]S
+	Goal  S
+	Found bool
+}
+
+// Path reconstructs the path from the start state to s. It returns nil if s wasn't reached.
+func (r Result[S]) Path(s S) []S {
+	if _, ok := r.Dist[s]; !ok {
+		return nil
+	}
+
+	path := []S{s}
+	for {
+		prev, ok := r.Prev[s]
+		if !ok {
+			break
+		}
+		path = append(path, prev)
+		s = prev
+	}
+
+	slices.Reverse(path)
+	return path
+}
+
+// BFS searches the graph breadth first, every step has a cost of 1.
+func BFS[S comparable](start S, next Neighbors[S], goal Goal[S]) Result[S] {
+	r := Result[S]{
+		Dist: map[S]int{start: 0},
+		Prev: make(map[S]S),
+	}
+
+	queue := collections.NewQueue(64, start)
+	for s := range queue.Drain() {
+		if goal != nil && goal(s) {
+			r.Goal = s
+			r.Found = true
+			return r
+		}
+
+		for n := range next(s) {
+			if _, ok := r.Dist[n]; ok {
+				continue
+			}
+
+			r.Dist[n] = r.Dist[s] + 1
+			r.Prev[n] = s
+			queue.PushBack(n)
+		}
+	}
+
+	return r
+}
+
+// Dijkstra finds the cheapest path from start to a goal. Costs must not be negative.
+func Dijkstra[S comparable](start S, next Neighbors[S], cost Cost[S], goal Goal[S]) Result[S] {
+	return AStar(start, next, cost, nil, goal)
+}
+
+// AStar finds the cheapest path from start to a goal guided by the heuristic h. A nil heuristic
+// turns it into Dijkstra's algorithm.
+func AStar[S comparable](start S, next Neighbors[S], cost Cost[S], h Heuristic[S], goal Goal[S]) Result[S] {
+	if h == nil {
+		h = func(S) int { return 0 }
+	}
+
+	r := Result[S]{
+		Dist: make(map[S]int),
+		Prev: make(map[S]S),
+	}
+
+	f := newFrontier(r.Dist, h)
+	defer f.finish()
+
+	f.relax(start, 0)
+	for s, prio := range f.open.Drain() {
+		if goal != nil && goal(s) {
+			r.Goal = s
+			r.Found = true
+			return r
+		}
+
+		dist := prio - h(s)
+		for n := range next(s) {
+			c := dist + cost(s, n)
+			if best, ok := f.relax(n, c); ok && best <= c {
+				continue
+			}
+
+			r.Prev[n] = s
+		}
+	}
+
+	return r
+}
+
+// frontier is the open set of a search together with the cost of every state seen so far. The
+// handles of the heap are dense, so the costs are kept in a slice indexed by handle and the Dist
+// map of the result holds the handle of each state until finish replaces them with the costs.
+// This saves a third map from states to handles.
+type frontier[S comparable] struct {
+	h     Heuristic[S]
+	open  collections.IndexedHeap[S, int]
+	index map[S]int
+	costs []int
+}
+
+func newFrontier[S comparable](dist map[S]int, h Heuristic[S]) *frontier[S] {
+	return &frontier[S]{
+		h:     h,
+		open:  collections.NewIndexedHeap[S, int](),
+		index: dist,
+	}
+}
+
+// relax lowers the cost of s to c if that is cheaper than the best cost found so far. s is then
+// pushed to the heap or its priority is lowered if it is still waiting there. It returns the
+// previous best cost.
+func (f *frontier[S]) relax(s S, c int) (best int, seen bool) {
+	it, seen := f.index[s]
+	if seen {
+		best = f.costs[it]
+		if best <= c {
+			return best, true
+		}
+	}
+
+	prio := c + f.h(s)
+	if seen && f.open.Contains(collections.Handle(it)) {
+		f.costs[it] = c
+		f.open.Update(collections.Handle(it), prio)
+		return best, true
+	}
+
+	f.index[s] = int(f.open.Push(s, prio))
+	f.costs = append(f.costs, c)
+	return best, seen
+}
+
+// finish replaces the handles in the Dist map with the costs.
+func (f *frontier[S]) finish() {
+	for s, it := range f.index {
+		f.index[s] = f.costs[it]
+	}
+}
+
+// DAG holds all cheapest paths found by AllShortest. Every state may have multiple predecessors.
+// Edges with a cost of 0 can form cycles in Prev.
+type DAG[S comparable] struct {
+	Start S
+	Dist  map[S]int
+	Prev  map[S][]S
+	Goals []S
+}
+
+// AllShortest runs Dijkstra's algorithm but keeps every predecessor on a cheapest path. It stops
+// once all goal states with the minimal cost have been found.
+func AllShortest[S comparable](start S, next Neighbors[S], cost Cost[S], goal Goal[S]) DAG[S] {
+	d := DAG[S]{
+		Start: start,
+		Dist:  make(map[S]int),
+		Prev:  make(map[S][]S),
+	}
+
+	found := false
+	limit := 0
+
+	f := newFrontier(d.Dist, func(S) int { return 0 })
+	defer f.finish()
+
+	f.relax(start, 0)
+	for s, c := range f.open.Drain() {
+		if found && c > limit {
+			break
+		}
+
+		if goal != nil && goal(s) {
+			d.Goals = append(d.Goals, s)
+			found = true
+			limit = c
+			continue
+		}
+
+		for n := range next(s) {
+			c := c + cost(s, n)
+			best, ok := f.relax(n, c)
+
+			switch {
+			case !ok || c < best:
+				d.Prev[n] = append(d.Prev[n][:0], s)
+			case c == best:
+				d.Prev[n] = append(d.Prev[n], s)
+			}
+		}
+	}
+
+	return d
+}
+
+// States returns all states which lie on a cheapest path to any of the given end states.
+func (d DAG[S]) States(ends ...S) collections.Set[S] {
+	seen := collections.Set[S]{}
+
+	queue := collections.NewQueue(64, ends...)
+	for s := range queue.Drain() {
+		if seen.Has(s) {
+			continue
+		}
+		seen.Put(s)
+
+		for _, prev := range d.Prev[s] {
+			queue.PushBack(prev)
+		}
+	}
+
+	return seen
+}
+
+// Paths iterates over all cheapest paths from the start state to end. Paths visiting a state twice
+// (only possible along edges with a cost of 0) are skipped.
+func (d DAG[S]) Paths(end S) iter.Seq[[]S] {
+	return func(yield func([]S) bool) {
+		if _, ok := d.Dist[end]; !ok {
+			return
+		}
+
+		onPath := collections.Set[S]{}
+
+		var walk func(s S, suffix []S) bool
+		walk = func(s S, suffix []S) bool {
+			suffix = append(suffix, s)
+
+			if s == d.Start {
+				path := append([]S(nil), suffix...)
+				slices.Reverse(path)
+				return yield(path)
+			}
+
+			onPath.Put(s)
+			defer delete(onPath, s)
+
+			for _, prev := range d.Prev[s] {
+				if onPath.Has(prev) {
+					continue
+				}
+
+				if !walk(prev, suffix) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		walk(end, nil)
+	}
+}
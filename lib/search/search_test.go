@@ -0,0 +1,173 @@
+package search
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/noxer/aoc/lib/geom"
+)
+
+// graph is an adjacency list with weighted edges.
+type graph map[int]map[int]int
+
+func (g graph) next(s int) iter.Seq[int] {
+	return maps.Keys(g[s])
+}
+
+func (g graph) cost(from, to int) int {
+	return g[from][to]
+}
+
+func is(goal int) Goal[int] {
+	return func(s int) bool { return s == goal }
+}
+
+func TestBFS(t *testing.T) {
+	g := graph{
+		0: {1: 1, 2: 1},
+		1: {3: 1},
+		2: {3: 1},
+		3: {4: 1},
+		5: {0: 1},
+	}
+
+	r := BFS(0, g.next, is(4))
+	if !r.Found || r.Goal != 4 || r.Dist[4] != 3 {
+		t.Fatalf("BFS(0, 4) = found %t, goal %d, dist %d", r.Found, r.Goal, r.Dist[4])
+	}
+	if got := r.Path(4); len(got) != 4 || got[0] != 0 || got[3] != 4 {
+		t.Errorf("Path(4) = %v", got)
+	}
+
+	r = BFS(0, g.next, is(5))
+	if r.Found {
+		t.Errorf("BFS found the unreachable goal 5")
+	}
+	if len(r.Dist) != 5 {
+		t.Errorf("BFS reached %d states, want all 5 reachable ones", len(r.Dist))
+	}
+	if got := r.Path(5); got != nil {
+		t.Errorf("Path(5) = %v, want nil", got)
+	}
+	if got := r.Path(0); !slices.Equal(got, []int{0}) {
+		t.Errorf("Path(0) = %v, want [0]", got)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	// the direct edge is more expensive than the detour
+	g := graph{
+		0: {1: 10, 2: 1},
+		2: {3: 1},
+		3: {1: 1},
+		1: {4: 1},
+	}
+
+	r := Dijkstra(0, g.next, g.cost, is(4))
+	if !r.Found || r.Dist[4] != 4 {
+		t.Fatalf("Dijkstra(0, 4) = found %t, dist %d, want 4", r.Found, r.Dist[4])
+	}
+	if got, want := r.Path(4), []int{0, 2, 3, 1, 4}; !slices.Equal(got, want) {
+		t.Errorf("Path(4) = %v, want %v", got, want)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	// an open 20x20 field with a wall from (10, 0) to (10, 18)
+	const size = 20
+	start, end := geom.Vec{X: 0, Y: 0}, geom.Vec{X: size - 1, Y: 0}
+
+	next := func(p geom.Vec) iter.Seq[geom.Vec] {
+		return func(yield func(geom.Vec) bool) {
+			for _, dir := range geom.Directions {
+				n := p.Add(dir)
+				if n.X < 0 || n.Y < 0 || n.X >= size || n.Y >= size || (n.X == 10 && n.Y < size-1) {
+					continue
+				}
+				if !yield(n) {
+					return
+				}
+			}
+		}
+	}
+	cost := func(geom.Vec, geom.Vec) int { return 1 }
+	h := func(p geom.Vec) int {
+		d := p.Sub(end)
+		return max(d.X, -d.X) + max(d.Y, -d.Y)
+	}
+	goal := func(p geom.Vec) bool { return p == end }
+
+	want := Dijkstra(start, next, cost, goal)
+	got := AStar(start, next, cost, h, goal)
+
+	if !got.Found || got.Dist[end] != want.Dist[end] || got.Dist[end] != 2*(size-1)+size-1 {
+		t.Fatalf("AStar dist = %d, Dijkstra dist = %d", got.Dist[end], want.Dist[end])
+	}
+	if path := got.Path(end); len(path) != got.Dist[end]+1 || path[0] != start {
+		t.Errorf("Path(end) has %d states, want %d", len(path), got.Dist[end]+1)
+	}
+	if len(got.Dist) >= len(want.Dist) {
+		t.Errorf("AStar visited %d states, Dijkstra %d", len(got.Dist), len(want.Dist))
+	}
+}
+
+func TestAllShortest(t *testing.T) {
+	// two cheapest paths to 3 and a more expensive one via 4
+	g := graph{
+		0: {1: 1, 2: 1, 4: 1},
+		1: {3: 1},
+		2: {3: 1},
+		4: {3: 5},
+		3: {5: 1},
+	}
+
+	d := AllShortest(0, g.next, g.cost, is(3))
+	if !slices.Equal(d.Goals, []int{3}) || d.Dist[3] != 2 {
+		t.Fatalf("AllShortest goals = %v, dist %d", d.Goals, d.Dist[3])
+	}
+
+	states := slices.Sorted(maps.Keys(d.States(3)))
+	if want := []int{0, 1, 2, 3}; !slices.Equal(states, want) {
+		t.Errorf("States(3) = %v, want %v", states, want)
+	}
+
+	var paths [][]int
+	for p := range d.Paths(3) {
+		paths = append(paths, p)
+	}
+	slices.SortFunc(paths, slices.Compare)
+	if len(paths) != 2 || !slices.Equal(paths[0], []int{0, 1, 3}) || !slices.Equal(paths[1], []int{0, 2, 3}) {
+		t.Errorf("Paths(3) = %v", paths)
+	}
+
+	if _, ok := d.Dist[5]; ok {
+		t.Errorf("AllShortest expanded the goal")
+	}
+}
+
+func TestAllShortestZeroCostCycle(t *testing.T) {
+	// 1 and 2 can step back and forth for free
+	g := graph{
+		0: {1: 0},
+		1: {2: 0, 0: 0},
+		2: {1: 0, 3: 1},
+	}
+
+	d := AllShortest(0, g.next, g.cost, is(3))
+	if d.Dist[3] != 1 {
+		t.Fatalf("Dist[3] = %d, want 1", d.Dist[3])
+	}
+
+	var paths [][]int
+	for p := range d.Paths(3) {
+		paths = append(paths, p)
+		if len(paths) > 10 {
+			t.Fatal("Paths(3) doesn't stop")
+		}
+	}
+	if len(paths) != 1 || !slices.Equal(paths[0], []int{0, 1, 2, 3}) {
+		t.Errorf("Paths(3) = %v, want [[0 1 2 3]]", paths)
+	}
+}
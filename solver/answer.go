@@ -0,0 +1,80 @@
+package solver
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// Kind describes which type of value an answer holds.
+type Kind int
+
+const (
+	KindNone Kind = iota
+	KindInt
+	KindString
+	KindBigInt
+)
+
+// Answer is the result of a task. Use Int, String or BigInt to construct one.
+type Answer struct {
+	kind Kind
+	i    int
+	s    string
+	b    *big.Int
+}
+
+// Int creates an integer answer.
+func Int(n int) Answer {
+	return Answer{kind: KindInt, i: n}
+}
+
+// String creates a textual answer.
+func String(s string) Answer {
+	return Answer{kind: KindString, s: s}
+}
+
+// BigInt creates an answer for results that don't fit into an int.
+func BigInt(n *big.Int) Answer {
+	return Answer{kind: KindBigInt, b: new(big.Int).Set(n)}
+}
+
+// Kind returns the type of the value held by the answer.
+func (a Answer) Kind() Kind {
+	return a.kind
+}
+
+// Int returns the value of an integer answer.
+func (a Answer) Int() (int, bool) {
+	return a.i, a.kind == KindInt
+}
+
+// BigInt returns the value of an integer answer as a big.Int, regardless of its size.
+func (a Answer) BigInt() (*big.Int, bool) {
+	switch a.kind {
+	case KindInt:
+		return big.NewInt(int64(a.i)), true
+	case KindBigInt:
+		return new(big.Int).Set(a.b), true
+	}
+
+	return nil, false
+}
+
+// Equal reports whether both answers are the same, compared by their textual representation.
+func (a Answer) Equal(o Answer) bool {
+	return a.kind != KindNone && o.kind != KindNone && a.String() == o.String()
+}
+
+// String formats the answer the way it is submitted.
+func (a Answer) String() string {
+	switch a.kind {
+	case KindInt:
+		return strconv.Itoa(a.i)
+	case KindString:
+		return a.s
+	case KindBigInt:
+		return a.b.String()
+	}
+
+	return ""
+}
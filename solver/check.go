@@ -0,0 +1,194 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Case is an input file together with the expected answers for it. Expected answers which are
+// left empty are reported as missing, parts listed in Skip are not executed at all (e.g. because
+// the example doesn't apply to that part).
+type Case struct {
+	Name  string   `json:"name"`
+	Input string   `json:"input,omitempty"`
+	Args  []string `json:"args,omitempty"`
+	Part1 string   `json:"part1,omitempty"`
+	Part2 string   `json:"part2,omitempty"`
+	Skip  []int    `json:"skip,omitempty"`
+}
+
+// Expected returns the expected answer for part 1 or 2.
+func (c Case) Expected(part int) string {
+	if part == 1 {
+		return c.Part1
+	}
+	return c.Part2
+}
+
+// Answers holds the cases of all days of a single year, keyed by the day.
+type Answers map[int][]Case
+
+// LoadAnswers reads an answers file. Input paths of the cases are resolved relative to the
+// directory containing the file.
+func LoadAnswers(name string) (Answers, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var answers Answers
+	if err = json.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", name, err)
+	}
+
+	dir := filepath.Dir(name)
+	for _, cases := range answers {
+		for i := range cases {
+			if cases[i].Input != "" {
+				cases[i].Input = filepath.Join(dir, cases[i].Input)
+			}
+		}
+	}
+
+	return answers, nil
+}
+
+// Status is the outcome of checking a single part of a case.
+type Status int
+
+const (
+	Pass Status = iota
+	Fail
+	Missing
+	Error
+)
+
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "PASS"
+	case Fail:
+		return "FAIL"
+	case Missing:
+		return "MISS"
+	case Error:
+		return "ERR "
+	}
+
+	return "????"
+}
+
+// Result describes the outcome of checking a single part of a case.
+type Result struct {
+	Day      Day
+	Case     string
+	Part     int
+	Status   Status
+	Expected string
+	Answer   Answer
+	Err      error
+	Elapsed  time.Duration
+}
+
+func (r Result) String() string {
+	if r.Part == 0 {
+		return fmt.Sprintf("%s %s: %s", r.Status, r.Day, r.Err)
+	}
+
+	prefix := fmt.Sprintf("%s %s part %d (%s)", r.Status, r.Day, r.Part, r.Case)
+
+	switch r.Status {
+	case Pass:
+		return fmt.Sprintf("%s: %s (%s)", prefix, r.Answer, r.Elapsed)
+	case Fail:
+		return fmt.Sprintf("%s: got %s, want %s (%s)", prefix, r.Answer, r.Expected, r.Elapsed)
+	case Error:
+		return fmt.Sprintf("%s: %s", prefix, r.Err)
+	}
+
+	if r.Err != nil {
+		return fmt.Sprintf("%s: %s", prefix, r.Err)
+	}
+	return fmt.Sprintf("%s: no expected answer, got %s", prefix, r.Answer)
+}
+
+// Check runs both parts of the day against all cases and compares the answers with the expected
+// ones. A day without any cases yields a single missing result.
+func Check(d Day, cases []Case) []Result {
+	if len(cases) == 0 {
+		return []Result{{
+			Day:    d,
+			Status: Missing,
+			Err:    errors.New("no cases"),
+		}}
+	}
+
+	var results []Result
+	for _, c := range cases {
+		for part := 1; part <= len(d.Tasks); part++ {
+			if slices.Contains(c.Skip, part) {
+				continue
+			}
+
+			results = append(results, checkPart(d, c, part))
+		}
+	}
+
+	return results
+}
+
+func checkPart(d Day, c Case, part int) Result {
+	r := Result{
+		Day:      d,
+		Case:     c.Name,
+		Part:     part,
+		Expected: c.Expected(part),
+	}
+
+	var args []string
+	if c.Input != "" {
+		if _, err := os.Stat(c.Input); errors.Is(err, fs.ErrNotExist) {
+			r.Status = Missing
+			r.Err = fmt.Errorf("input %s not found", c.Input)
+			return r
+		}
+
+		args = append(args, c.Input)
+	}
+	args = append(args, c.Args...)
+
+	start := time.Now()
+	r.Answer, r.Err = run(d, part, args)
+	r.Elapsed = time.Since(start)
+
+	switch {
+	case r.Err != nil:
+		r.Status = Error
+	case r.Expected == "":
+		r.Status = Missing
+	case r.Answer.String() == r.Expected:
+		r.Status = Pass
+	default:
+		r.Status = Fail
+	}
+
+	return r
+}
+
+// run executes a part and turns a panic into an error, so one broken day doesn't stop the others
+// from being checked.
+func run(d Day, part int, args []string) (a Answer, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return d.Run(part, args)
+}
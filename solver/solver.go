@@ -0,0 +1,138 @@
+// Package solver keeps a registry of all puzzle solutions, so they can be executed from a single binary.
+package solver
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/noxer/aoc/lib/debug"
+	"github.com/noxer/aoc/lib/dot"
+)
+
+// Task solves one part of a puzzle. The arguments are passed through from the command line.
+type Task func(args []string) (Answer, error)
+
+// GraphFunc exports the graph a puzzle builds from its input, for debugging with Graphviz.
+type GraphFunc func(args []string) (dot.Grapher, error)
+
+// DebugFunc loads the machine a puzzle simulates, so it can be stepped through in the debugger.
+type DebugFunc func(args []string) (debug.Steppable, error)
+
+// Day holds the solutions for both parts of a single puzzle. Builtin puzzles have their input
+// compiled in and don't expect an input file.
+type Day struct {
+	Year    int
+	Day     int
+	Tasks   [2]Task
+	Builtin bool
+	Graph   GraphFunc
+	Debug   DebugFunc
+}
+
+// Run executes part 1 or 2 of the puzzle.
+func (d Day) Run(part int, args []string) (Answer, error) {
+	if part < 1 || part > len(d.Tasks) {
+		return Answer{}, fmt.Errorf("invalid part %d, please specify the task you want to execute (1 or 2)", part)
+	}
+	if !d.Builtin && len(args) == 0 {
+		return Answer{}, fmt.Errorf("missing input file for %s", d)
+	}
+
+	return d.Tasks[part-1](args)
+}
+
+func (d Day) String() string {
+	return fmt.Sprintf("%d day %02d", d.Year, d.Day)
+}
+
+type key struct {
+	year, day int
+}
+
+var (
+	mu   sync.RWMutex
+	days = make(map[key]Day)
+)
+
+// Register adds the solutions of a puzzle to the registry. It is meant to be called from the init
+// function of the package solving the puzzle and panics if the puzzle has been registered before.
+func Register(year, day int, task1, task2 Task) {
+	register(Day{Year: year, Day: day, Tasks: [2]Task{task1, task2}})
+}
+
+// RegisterBuiltin works like Register for puzzles with the input compiled into the solution.
+func RegisterBuiltin(year, day int, task1, task2 Task) {
+	register(Day{Year: year, Day: day, Tasks: [2]Task{task1, task2}, Builtin: true})
+}
+
+func register(d Day) {
+	if d.Tasks[0] == nil || d.Tasks[1] == nil {
+		panic(fmt.Sprintf("solver: missing task for %s", d))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	k := key{d.Year, d.Day}
+	if _, ok := days[k]; ok {
+		panic(fmt.Sprintf("solver: %s registered twice", d))
+	}
+
+	days[k] = d
+}
+
+// RegisterGraph adds a graph export to a registered puzzle. It panics if the puzzle hasn't been
+// registered before.
+func RegisterGraph(year, day int, f GraphFunc) {
+	update(year, day, "graph", func(d *Day) { d.Graph = f })
+}
+
+// RegisterDebug adds a debugger hook to a registered puzzle. It panics if the puzzle hasn't been
+// registered before.
+func RegisterDebug(year, day int, f DebugFunc) {
+	update(year, day, "debugger", func(d *Day) { d.Debug = f })
+}
+
+func update(year, day int, what string, f func(d *Day)) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	k := key{year, day}
+	d, ok := days[k]
+	if !ok {
+		panic(fmt.Sprintf("solver: %s for unknown %d day %02d", what, year, day))
+	}
+
+	f(&d)
+	days[k] = d
+}
+
+// Lookup returns the solutions for the given puzzle.
+func Lookup(year, day int) (Day, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	d, ok := days[key{year, day}]
+	return d, ok
+}
+
+// Days returns all registered puzzles ordered by year and day.
+func Days() []Day {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	ds := make([]Day, 0, len(days))
+	for _, d := range days {
+		ds = append(ds, d)
+	}
+
+	slices.SortFunc(ds, func(a, b Day) int {
+		if a.Year != b.Year {
+			return a.Year - b.Year
+		}
+		return a.Day - b.Day
+	})
+
+	return ds
+}